@@ -20,9 +20,15 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	LesterService_ProposeHeistOffer_FullMethodName        = "/heist.LesterService/ProposeHeistOffer"
+	LesterService_ListOffers_FullMethodName               = "/heist.LesterService/ListOffers"
 	LesterService_DecideOnOffer_FullMethodName            = "/heist.LesterService/DecideOnOffer"
 	LesterService_ManageStarsNotifications_FullMethodName = "/heist.LesterService/ManageStarsNotifications"
+	LesterService_ListNotificationSessions_FullMethodName = "/heist.LesterService/ListNotificationSessions"
+	LesterService_RenewStarsSession_FullMethodName        = "/heist.LesterService/RenewStarsSession"
 	LesterService_ConfirmCut_FullMethodName               = "/heist.LesterService/ConfirmCut"
+	LesterService_RegisterOperator_FullMethodName         = "/heist.LesterService/RegisterOperator"
+	LesterService_OperatorHeartbeat_FullMethodName        = "/heist.LesterService/OperatorHeartbeat"
+	LesterService_ListCrew_FullMethodName                 = "/heist.LesterService/ListCrew"
 )
 
 // LesterServiceClient is the client API for LesterService service.
@@ -30,9 +36,15 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LesterServiceClient interface {
 	ProposeHeistOffer(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HeistOffer, error)
+	ListOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferBoard, error)
 	DecideOnOffer(ctx context.Context, in *Decision, opts ...grpc.CallOption) (*Empty, error)
-	ManageStarsNotifications(ctx context.Context, in *NotificationCommand, opts ...grpc.CallOption) (*Empty, error)
+	ManageStarsNotifications(ctx context.Context, in *NotificationCommand, opts ...grpc.CallOption) (*NotificationSession, error)
+	ListNotificationSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NotificationSessions, error)
+	RenewStarsSession(ctx context.Context, in *SessionHeartbeat, opts ...grpc.CallOption) (*NotificationSession, error)
 	ConfirmCut(ctx context.Context, in *CutDetails, opts ...grpc.CallOption) (*Ack, error)
+	RegisterOperator(ctx context.Context, in *OperatorRegistration, opts ...grpc.CallOption) (*RegistrationLease, error)
+	OperatorHeartbeat(ctx context.Context, in *Heartbeat, opts ...grpc.CallOption) (*RegistrationLease, error)
+	ListCrew(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Crew, error)
 }
 
 type lesterServiceClient struct {
@@ -53,6 +65,16 @@ func (c *lesterServiceClient) ProposeHeistOffer(ctx context.Context, in *Empty,
 	return out, nil
 }
 
+func (c *lesterServiceClient) ListOffers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OfferBoard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferBoard)
+	err := c.cc.Invoke(ctx, LesterService_ListOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lesterServiceClient) DecideOnOffer(ctx context.Context, in *Decision, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -63,9 +85,9 @@ func (c *lesterServiceClient) DecideOnOffer(ctx context.Context, in *Decision, o
 	return out, nil
 }
 
-func (c *lesterServiceClient) ManageStarsNotifications(ctx context.Context, in *NotificationCommand, opts ...grpc.CallOption) (*Empty, error) {
+func (c *lesterServiceClient) ManageStarsNotifications(ctx context.Context, in *NotificationCommand, opts ...grpc.CallOption) (*NotificationSession, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(NotificationSession)
 	err := c.cc.Invoke(ctx, LesterService_ManageStarsNotifications_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -73,6 +95,26 @@ func (c *lesterServiceClient) ManageStarsNotifications(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *lesterServiceClient) ListNotificationSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NotificationSessions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationSessions)
+	err := c.cc.Invoke(ctx, LesterService_ListNotificationSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lesterServiceClient) RenewStarsSession(ctx context.Context, in *SessionHeartbeat, opts ...grpc.CallOption) (*NotificationSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationSession)
+	err := c.cc.Invoke(ctx, LesterService_RenewStarsSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lesterServiceClient) ConfirmCut(ctx context.Context, in *CutDetails, opts ...grpc.CallOption) (*Ack, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Ack)
@@ -83,14 +125,50 @@ func (c *lesterServiceClient) ConfirmCut(ctx context.Context, in *CutDetails, op
 	return out, nil
 }
 
+func (c *lesterServiceClient) RegisterOperator(ctx context.Context, in *OperatorRegistration, opts ...grpc.CallOption) (*RegistrationLease, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationLease)
+	err := c.cc.Invoke(ctx, LesterService_RegisterOperator_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lesterServiceClient) OperatorHeartbeat(ctx context.Context, in *Heartbeat, opts ...grpc.CallOption) (*RegistrationLease, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationLease)
+	err := c.cc.Invoke(ctx, LesterService_OperatorHeartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lesterServiceClient) ListCrew(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Crew, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Crew)
+	err := c.cc.Invoke(ctx, LesterService_ListCrew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LesterServiceServer is the server API for LesterService service.
 // All implementations must embed UnimplementedLesterServiceServer
 // for forward compatibility.
 type LesterServiceServer interface {
 	ProposeHeistOffer(context.Context, *Empty) (*HeistOffer, error)
+	ListOffers(context.Context, *Empty) (*OfferBoard, error)
 	DecideOnOffer(context.Context, *Decision) (*Empty, error)
-	ManageStarsNotifications(context.Context, *NotificationCommand) (*Empty, error)
+	ManageStarsNotifications(context.Context, *NotificationCommand) (*NotificationSession, error)
+	ListNotificationSessions(context.Context, *Empty) (*NotificationSessions, error)
+	RenewStarsSession(context.Context, *SessionHeartbeat) (*NotificationSession, error)
 	ConfirmCut(context.Context, *CutDetails) (*Ack, error)
+	RegisterOperator(context.Context, *OperatorRegistration) (*RegistrationLease, error)
+	OperatorHeartbeat(context.Context, *Heartbeat) (*RegistrationLease, error)
+	ListCrew(context.Context, *Empty) (*Crew, error)
 	mustEmbedUnimplementedLesterServiceServer()
 }
 
@@ -104,15 +182,33 @@ type UnimplementedLesterServiceServer struct{}
 func (UnimplementedLesterServiceServer) ProposeHeistOffer(context.Context, *Empty) (*HeistOffer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProposeHeistOffer not implemented")
 }
+func (UnimplementedLesterServiceServer) ListOffers(context.Context, *Empty) (*OfferBoard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOffers not implemented")
+}
 func (UnimplementedLesterServiceServer) DecideOnOffer(context.Context, *Decision) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DecideOnOffer not implemented")
 }
-func (UnimplementedLesterServiceServer) ManageStarsNotifications(context.Context, *NotificationCommand) (*Empty, error) {
+func (UnimplementedLesterServiceServer) ManageStarsNotifications(context.Context, *NotificationCommand) (*NotificationSession, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ManageStarsNotifications not implemented")
 }
+func (UnimplementedLesterServiceServer) ListNotificationSessions(context.Context, *Empty) (*NotificationSessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotificationSessions not implemented")
+}
+func (UnimplementedLesterServiceServer) RenewStarsSession(context.Context, *SessionHeartbeat) (*NotificationSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewStarsSession not implemented")
+}
 func (UnimplementedLesterServiceServer) ConfirmCut(context.Context, *CutDetails) (*Ack, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmCut not implemented")
 }
+func (UnimplementedLesterServiceServer) RegisterOperator(context.Context, *OperatorRegistration) (*RegistrationLease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterOperator not implemented")
+}
+func (UnimplementedLesterServiceServer) OperatorHeartbeat(context.Context, *Heartbeat) (*RegistrationLease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OperatorHeartbeat not implemented")
+}
+func (UnimplementedLesterServiceServer) ListCrew(context.Context, *Empty) (*Crew, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCrew not implemented")
+}
 func (UnimplementedLesterServiceServer) mustEmbedUnimplementedLesterServiceServer() {}
 func (UnimplementedLesterServiceServer) testEmbeddedByValue()                       {}
 
@@ -152,6 +248,24 @@ func _LesterService_ProposeHeistOffer_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LesterService_ListOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).ListOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_ListOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).ListOffers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LesterService_DecideOnOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Decision)
 	if err := dec(in); err != nil {
@@ -188,6 +302,42 @@ func _LesterService_ManageStarsNotifications_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LesterService_ListNotificationSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).ListNotificationSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_ListNotificationSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).ListNotificationSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LesterService_RenewStarsSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionHeartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).RenewStarsSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_RenewStarsSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).RenewStarsSession(ctx, req.(*SessionHeartbeat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LesterService_ConfirmCut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CutDetails)
 	if err := dec(in); err != nil {
@@ -206,6 +356,60 @@ func _LesterService_ConfirmCut_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LesterService_RegisterOperator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OperatorRegistration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).RegisterOperator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_RegisterOperator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).RegisterOperator(ctx, req.(*OperatorRegistration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LesterService_OperatorHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Heartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).OperatorHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_OperatorHeartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).OperatorHeartbeat(ctx, req.(*Heartbeat))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LesterService_ListCrew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LesterServiceServer).ListCrew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LesterService_ListCrew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LesterServiceServer).ListCrew(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LesterService_ServiceDesc is the grpc.ServiceDesc for LesterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +421,10 @@ var LesterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProposeHeistOffer",
 			Handler:    _LesterService_ProposeHeistOffer_Handler,
 		},
+		{
+			MethodName: "ListOffers",
+			Handler:    _LesterService_ListOffers_Handler,
+		},
 		{
 			MethodName: "DecideOnOffer",
 			Handler:    _LesterService_DecideOnOffer_Handler,
@@ -225,10 +433,30 @@ var LesterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ManageStarsNotifications",
 			Handler:    _LesterService_ManageStarsNotifications_Handler,
 		},
+		{
+			MethodName: "ListNotificationSessions",
+			Handler:    _LesterService_ListNotificationSessions_Handler,
+		},
+		{
+			MethodName: "RenewStarsSession",
+			Handler:    _LesterService_RenewStarsSession_Handler,
+		},
 		{
 			MethodName: "ConfirmCut",
 			Handler:    _LesterService_ConfirmCut_Handler,
 		},
+		{
+			MethodName: "RegisterOperator",
+			Handler:    _LesterService_RegisterOperator_Handler,
+		},
+		{
+			MethodName: "OperatorHeartbeat",
+			Handler:    _LesterService_OperatorHeartbeat_Handler,
+		},
+		{
+			MethodName: "ListCrew",
+			Handler:    _LesterService_ListCrew_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/heist.proto",
@@ -240,6 +468,8 @@ const (
 	OperatorService_StartHit_FullMethodName               = "/heist.OperatorService/StartHit"
 	OperatorService_RetrieveLoot_FullMethodName           = "/heist.OperatorService/RetrieveLoot"
 	OperatorService_ConfirmCut_FullMethodName             = "/heist.OperatorService/ConfirmCut"
+	OperatorService_PhaseHistory_FullMethodName           = "/heist.OperatorService/PhaseHistory"
+	OperatorService_AbortPhase_FullMethodName             = "/heist.OperatorService/AbortPhase"
 )
 
 // OperatorServiceClient is the client API for OperatorService service.
@@ -247,10 +477,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OperatorServiceClient interface {
 	StartDistraction(ctx context.Context, in *DistractionDetails, opts ...grpc.CallOption) (*Empty, error)
-	CheckDistractionStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PhaseStatus, error)
+	CheckDistractionStatus(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*PhaseStatus, error)
 	StartHit(ctx context.Context, in *HitDetails, opts ...grpc.CallOption) (*Empty, error)
-	RetrieveLoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LootDetails, error)
+	RetrieveLoot(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*LootDetails, error)
 	ConfirmCut(ctx context.Context, in *CutDetails, opts ...grpc.CallOption) (*Ack, error)
+	PhaseHistory(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*StateHistory, error)
+	AbortPhase(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*PhaseStatus, error)
 }
 
 type operatorServiceClient struct {
@@ -271,7 +503,7 @@ func (c *operatorServiceClient) StartDistraction(ctx context.Context, in *Distra
 	return out, nil
 }
 
-func (c *operatorServiceClient) CheckDistractionStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PhaseStatus, error) {
+func (c *operatorServiceClient) CheckDistractionStatus(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*PhaseStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PhaseStatus)
 	err := c.cc.Invoke(ctx, OperatorService_CheckDistractionStatus_FullMethodName, in, out, cOpts...)
@@ -291,7 +523,7 @@ func (c *operatorServiceClient) StartHit(ctx context.Context, in *HitDetails, op
 	return out, nil
 }
 
-func (c *operatorServiceClient) RetrieveLoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LootDetails, error) {
+func (c *operatorServiceClient) RetrieveLoot(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*LootDetails, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LootDetails)
 	err := c.cc.Invoke(ctx, OperatorService_RetrieveLoot_FullMethodName, in, out, cOpts...)
@@ -311,15 +543,37 @@ func (c *operatorServiceClient) ConfirmCut(ctx context.Context, in *CutDetails,
 	return out, nil
 }
 
+func (c *operatorServiceClient) PhaseHistory(ctx context.Context, in *OperatorRequest, opts ...grpc.CallOption) (*StateHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateHistory)
+	err := c.cc.Invoke(ctx, OperatorService_PhaseHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operatorServiceClient) AbortPhase(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*PhaseStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PhaseStatus)
+	err := c.cc.Invoke(ctx, OperatorService_AbortPhase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OperatorServiceServer is the server API for OperatorService service.
 // All implementations must embed UnimplementedOperatorServiceServer
 // for forward compatibility.
 type OperatorServiceServer interface {
 	StartDistraction(context.Context, *DistractionDetails) (*Empty, error)
-	CheckDistractionStatus(context.Context, *Empty) (*PhaseStatus, error)
+	CheckDistractionStatus(context.Context, *OperatorRequest) (*PhaseStatus, error)
 	StartHit(context.Context, *HitDetails) (*Empty, error)
-	RetrieveLoot(context.Context, *Empty) (*LootDetails, error)
+	RetrieveLoot(context.Context, *OperatorRequest) (*LootDetails, error)
 	ConfirmCut(context.Context, *CutDetails) (*Ack, error)
+	PhaseHistory(context.Context, *OperatorRequest) (*StateHistory, error)
+	AbortPhase(context.Context, *AbortRequest) (*PhaseStatus, error)
 	mustEmbedUnimplementedOperatorServiceServer()
 }
 
@@ -333,18 +587,24 @@ type UnimplementedOperatorServiceServer struct{}
 func (UnimplementedOperatorServiceServer) StartDistraction(context.Context, *DistractionDetails) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartDistraction not implemented")
 }
-func (UnimplementedOperatorServiceServer) CheckDistractionStatus(context.Context, *Empty) (*PhaseStatus, error) {
+func (UnimplementedOperatorServiceServer) CheckDistractionStatus(context.Context, *OperatorRequest) (*PhaseStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckDistractionStatus not implemented")
 }
 func (UnimplementedOperatorServiceServer) StartHit(context.Context, *HitDetails) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartHit not implemented")
 }
-func (UnimplementedOperatorServiceServer) RetrieveLoot(context.Context, *Empty) (*LootDetails, error) {
+func (UnimplementedOperatorServiceServer) RetrieveLoot(context.Context, *OperatorRequest) (*LootDetails, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveLoot not implemented")
 }
 func (UnimplementedOperatorServiceServer) ConfirmCut(context.Context, *CutDetails) (*Ack, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmCut not implemented")
 }
+func (UnimplementedOperatorServiceServer) PhaseHistory(context.Context, *OperatorRequest) (*StateHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PhaseHistory not implemented")
+}
+func (UnimplementedOperatorServiceServer) AbortPhase(context.Context, *AbortRequest) (*PhaseStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortPhase not implemented")
+}
 func (UnimplementedOperatorServiceServer) mustEmbedUnimplementedOperatorServiceServer() {}
 func (UnimplementedOperatorServiceServer) testEmbeddedByValue()                         {}
 
@@ -385,7 +645,7 @@ func _OperatorService_StartDistraction_Handler(srv interface{}, ctx context.Cont
 }
 
 func _OperatorService_CheckDistractionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(OperatorRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -397,7 +657,7 @@ func _OperatorService_CheckDistractionStatus_Handler(srv interface{}, ctx contex
 		FullMethod: OperatorService_CheckDistractionStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OperatorServiceServer).CheckDistractionStatus(ctx, req.(*Empty))
+		return srv.(OperatorServiceServer).CheckDistractionStatus(ctx, req.(*OperatorRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -421,7 +681,7 @@ func _OperatorService_StartHit_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _OperatorService_RetrieveLoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(OperatorRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -433,7 +693,7 @@ func _OperatorService_RetrieveLoot_Handler(srv interface{}, ctx context.Context,
 		FullMethod: OperatorService_RetrieveLoot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OperatorServiceServer).RetrieveLoot(ctx, req.(*Empty))
+		return srv.(OperatorServiceServer).RetrieveLoot(ctx, req.(*OperatorRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -456,6 +716,42 @@ func _OperatorService_ConfirmCut_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OperatorService_PhaseHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OperatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorServiceServer).PhaseHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorService_PhaseHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorServiceServer).PhaseHistory(ctx, req.(*OperatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperatorService_AbortPhase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperatorServiceServer).AbortPhase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OperatorService_AbortPhase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperatorServiceServer).AbortPhase(ctx, req.(*AbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OperatorService_ServiceDesc is the grpc.ServiceDesc for OperatorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -483,6 +779,14 @@ var OperatorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConfirmCut",
 			Handler:    _OperatorService_ConfirmCut_Handler,
 		},
+		{
+			MethodName: "PhaseHistory",
+			Handler:    _OperatorService_PhaseHistory_Handler,
+		},
+		{
+			MethodName: "AbortPhase",
+			Handler:    _OperatorService_AbortPhase_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/heist.proto",
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "franklin/proto"
+)
+
+const (
+	registryTimeout   = 3 * time.Second
+	registrationRetry = 5 * time.Second
+)
+
+// advertiseAddress is where Michael can reach this operator: ADVERTISE_ADDR,
+// or the hostname and the given port.
+func advertiseAddress(port string) string {
+	if addr := os.Getenv("ADVERTISE_ADDR"); addr != "" {
+		return addr
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// keepRegistered registers with Lester's crew registry and keeps the
+// registration alive with heartbeats. If Lester no longer knows this operator,
+// because it restarted or the registration expired, it registers again.
+func keepRegistered(registration *pb.OperatorRegistration) {
+	lesterHost := os.Getenv("LESTER_HOST")
+	if lesterHost == "" {
+		lesterHost = "192.168.1.6"
+	}
+	conn, err := grpc.Dial(lesterHost+":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("Could not connect to Lester's crew registry: %v", err)
+		return
+	}
+	defer conn.Close()
+	client := pb.NewLesterServiceClient(conn)
+
+	registered := false
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), registryTimeout)
+		var lease *pb.RegistrationLease
+		if registered {
+			lease, err = client.OperatorHeartbeat(ctx, &pb.Heartbeat{Name: registration.Name, Address: registration.Address})
+		} else {
+			lease, err = client.RegisterOperator(ctx, registration)
+		}
+		cancel()
+
+		wait := registrationRetry
+		switch {
+		case err == nil:
+			if !registered {
+				log.Printf("Registered with Lester as %s at %s", registration.Name, registration.Address)
+			}
+			registered = true
+			wait = time.Duration(lease.HeartbeatMs) * time.Millisecond
+		case status.Code(err) == codes.NotFound:
+			log.Printf("Lester dropped our registration, registering again")
+			registered = false
+			wait = 0
+		default:
+			log.Printf("Could not reach Lester's crew registry: %v", err)
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "franklin/proto"
+)
+
+// hitRequest is a StartHit call through the request log, counting the hits
+// it actually starts.
+type hitRequest struct {
+	id      string
+	turns   int32
+	restart bool // reload the request log from its file first
+	want    codes.Code
+	runs    int // hits started so far
+}
+
+func TestRequestLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		state pb.StateTransition_State
+		calls []hitRequest
+	}{
+		{
+			name:  "repeat replays the reply",
+			state: pb.StateTransition_AWAITING_ORDERS,
+			calls: []hitRequest{
+				{id: "h1", turns: 10, want: codes.OK, runs: 1},
+				{id: "h1", turns: 10, want: codes.OK, runs: 1},
+			},
+		},
+		{
+			name:  "repeat after a restart",
+			state: pb.StateTransition_AWAITING_ORDERS,
+			calls: []hitRequest{
+				{id: "h1", turns: 10, want: codes.OK, runs: 1},
+				{id: "h1", turns: 10, restart: true, want: codes.OK, runs: 1},
+			},
+		},
+		{
+			name:  "new hit while one is running",
+			state: pb.StateTransition_AWAITING_ORDERS,
+			calls: []hitRequest{
+				{id: "h1", turns: 10, want: codes.OK, runs: 1},
+				{id: "h2", turns: 10, want: codes.FailedPrecondition, runs: 2},
+			},
+		},
+		{
+			name:  "request ID reused for a different request",
+			state: pb.StateTransition_AWAITING_ORDERS,
+			calls: []hitRequest{
+				{id: "h1", turns: 10, want: codes.OK, runs: 1},
+				{id: "h1", turns: 20, want: codes.InvalidArgument, runs: 1},
+			},
+		},
+		{
+			name:  "failed requests are not remembered",
+			state: pb.StateTransition_LOOT_READY,
+			calls: []hitRequest{
+				{id: "h1", turns: 10, want: codes.FailedPrecondition, runs: 1},
+				{id: "h1", turns: 10, want: codes.FailedPrecondition, runs: 2},
+			},
+		},
+		{
+			name:  "requests without an ID always run",
+			state: pb.StateTransition_AWAITING_ORDERS,
+			calls: []hitRequest{
+				{turns: 10, want: codes.OK, runs: 1},
+				{turns: 10, want: codes.FailedPrecondition, runs: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateFile = filepath.Join(t.TempDir(), "state.json")
+			phaseState.state, phaseState.history = tt.state, nil
+			path := filepath.Join(t.TempDir(), "requests.json")
+			l := newTestRequestLog(t, path)
+			runs := 0
+			for i, call := range tt.calls {
+				if call.restart {
+					l = newTestRequestLog(t, path)
+				}
+				details := &pb.HitDetails{RequestId: call.id, TurnsNeeded: call.turns}
+				_, err := l.do(call.id, "StartHit", details, func() (proto.Message, error) {
+					runs++
+					if err := startPhase(eventStartHit, details.TurnsNeeded, "heist", 1000); err != nil {
+						return nil, err
+					}
+					return &pb.Empty{}, nil
+				})
+				if got := status.Code(err); got != call.want {
+					t.Errorf("call %d: got %v (%v), want %v", i, got, err, call.want)
+				}
+				if runs != call.runs {
+					t.Errorf("call %d: %d hits started, want %d", i, runs, call.runs)
+				}
+			}
+		})
+	}
+}
+
+// TestConcurrentRepeatsRunOnce has Michael's retries race the original call.
+func TestConcurrentRepeatsRunOnce(t *testing.T) {
+	l := newTestRequestLog(t, filepath.Join(t.TempDir(), "requests.json"))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		runs int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			details := &pb.HitDetails{RequestId: "h1", TurnsNeeded: 10}
+			_, err := l.do("h1", "StartHit", details, func() (proto.Message, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				runs++
+				return &pb.Empty{}, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if runs != 1 {
+		t.Errorf("the request ran %d times, want once", runs)
+	}
+}
+
+func newTestRequestLog(t *testing.T, path string) *requestLog {
+	t.Helper()
+	l := &requestLog{results: make(map[string]requestResult), running: make(map[string]*runningRequest)}
+	if err := l.load(path); err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
@@ -0,0 +1,194 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/protobuf/proto"
+
+	pb "franklin/proto"
+)
+
+// These tests need a RabbitMQ broker on RABBITMQ_HOST:5673 with the
+// admin/admin user, such as the one `make test-stars` starts.
+
+const (
+	totalUpdates  = 20
+	crashAfter    = 7
+	deliveryWait  = 5 * time.Second
+	brokerUserURL = "amqp://admin:admin@"
+)
+
+// TestStarsSurviveRestart has the operator crash after applying an update but
+// before acking it, then restart from its saved state. Every update must be
+// applied exactly once.
+func TestStarsSurviveRestart(t *testing.T) {
+	host := os.Getenv("RABBITMQ_HOST")
+	if host == "" {
+		t.Skip("RABBITMQ_HOST is not set")
+	}
+	reliableStars = true
+	stateFile = filepath.Join(t.TempDir(), "state.json")
+	heistID := fmt.Sprintf("redelivery-%d", time.Now().UnixNano())
+	exchange := starsExchangeName + "." + heistID
+
+	phaseState.mu.Lock()
+	phaseState.state, phaseState.heistID = pb.StateTransition_HITTING, heistID
+	phaseState.current_stars, phaseState.starSequence = 0, 0
+	phaseState.mu.Unlock()
+
+	sub, err := subscribeStars(heistID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { deleteStars(t, host, sub.queue, exchange) })
+	publishStarUpdates(t, host, exchange, heistID)
+
+	var applied []int64
+	for i := 0; i < crashAfter; i++ {
+		d, update := nextStarUpdate(t, sub)
+		if sub.apply(update) {
+			applied = append(applied, update.Sequence)
+		}
+		if err := d.Ack(false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, update := nextStarUpdate(t, sub)
+	if sub.apply(update) {
+		applied = append(applied, update.Sequence)
+	}
+	// Crash before the ack.
+	sub.conn.Close()
+
+	// Restart: forget everything but the saved state.
+	phaseState.mu.Lock()
+	phaseState.state, phaseState.heistID = pb.StateTransition_AWAITING_ORDERS, ""
+	phaseState.current_stars, phaseState.starSequence = 0, 0
+	found, err := loadState()
+	lastSequence := phaseState.starSequence
+	phaseState.mu.Unlock()
+	if err != nil || !found {
+		t.Fatalf("loading the saved state: found %t, %v", found, err)
+	}
+	sub, err = subscribeStars(heistID, lastSequence)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.conn.Close()
+
+	redelivered := false
+	for {
+		d, update := nextStarUpdate(t, sub)
+		redelivered = redelivered || d.Redelivered
+		if sub.apply(update) {
+			applied = append(applied, update.Sequence)
+		}
+		if err := d.Ack(false); err != nil {
+			t.Fatal(err)
+		}
+		if update.Sequence == totalUpdates {
+			break
+		}
+	}
+
+	if !redelivered {
+		t.Error("the update left unacked by the crash was not redelivered")
+	}
+	if len(applied) != totalUpdates {
+		t.Fatalf("applied updates %v, want 1 to %d once each", applied, totalUpdates)
+	}
+	for i, sequence := range applied {
+		if sequence != int64(i+1) {
+			t.Fatalf("applied updates %v, want 1 to %d once each", applied, totalUpdates)
+		}
+	}
+	phaseState.mu.Lock()
+	defer phaseState.mu.Unlock()
+	if phaseState.current_stars != totalUpdates {
+		t.Errorf("ended at %d stars, want %d", phaseState.current_stars, totalUpdates)
+	}
+}
+
+// publishStarUpdates publishes updates 1 to totalUpdates as Lester does in
+// reliable mode, waiting for the broker to confirm each one.
+func publishStarUpdates(t *testing.T, host, exchange, heistID string) {
+	t.Helper()
+	conn, err := amqp.Dial(brokerUserURL + host + ":5673/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		t.Fatal(err)
+	}
+	for sequence := int64(1); sequence <= totalUpdates; sequence++ {
+		body, err := proto.Marshal(&pb.StarUpdate{
+			HeistId:     heistID,
+			Sequence:    sequence,
+			Stars:       int32(sequence),
+			TimestampMs: time.Now().UnixMilli(),
+			Reason:      "test",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		confirmation, err := ch.PublishWithDeferredConfirmWithContext(context.Background(), exchange, "", false, false,
+			amqp.Publishing{DeliveryMode: amqp.Persistent, Body: body})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !confirmation.Wait() {
+			t.Fatalf("broker nacked star update #%d", sequence)
+		}
+	}
+}
+
+func nextStarUpdate(t *testing.T, sub *starSubscription) (amqp.Delivery, *pb.StarUpdate) {
+	t.Helper()
+	select {
+	case d, ok := <-sub.msgs:
+		if !ok {
+			t.Fatal("the subscription closed")
+		}
+		update, err := sub.decode(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d, update
+	case <-time.After(deliveryWait):
+		t.Fatalf("no star update after #%d within %s", sub.lastSequence, deliveryWait)
+	}
+	return amqp.Delivery{}, nil
+}
+
+func deleteStars(t *testing.T, host, queue, exchange string) {
+	conn, err := amqp.Dial(brokerUserURL + host + ":5673/")
+	if err != nil {
+		t.Log(err)
+		return
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Log(err)
+		return
+	}
+	if _, err := ch.QueueDelete(queue, false, false, false); err != nil {
+		t.Log(err)
+	}
+	if err := ch.ExchangeDelete(exchange, false, false); err != nil {
+		t.Log(err)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "franklin/proto"
+)
+
+const maxTransitions = 100
+
+// Operator events. Each one moves the operator from one of a few states to a
+// new one, see stateMachine.
+const (
+	eventStartDistraction = "start distraction"
+	eventDistracted       = "distraction succeeded"
+	eventStartHit         = "start hit"
+	eventHitDone          = "hit succeeded"
+	eventPhaseFailed      = "phase failed"
+	eventAbort            = "abort the phase"
+	eventRetrieveLoot     = "retrieve loot"
+	eventPaid             = "cut confirmed"
+	// eventInterrupted is only recorded in the history: the phase keeps its
+	// state until recoverState applies recoveryPolicy to it.
+	eventInterrupted = "interrupted by a shutdown"
+)
+
+type edge struct {
+	from []pb.StateTransition_State
+	to   pb.StateTransition_State
+}
+
+// idle are the states in which the operator takes a new phase. An operator
+// holding loot does not, until the loot is split.
+var idle = []pb.StateTransition_State{
+	pb.StateTransition_AWAITING_ORDERS,
+	pb.StateTransition_DISTRACTED,
+	pb.StateTransition_PAID,
+	pb.StateTransition_FAILED,
+}
+
+var stateMachine = map[string]edge{
+	eventStartDistraction: {idle, pb.StateTransition_DISTRACTING},
+	eventDistracted:       {[]pb.StateTransition_State{pb.StateTransition_DISTRACTING}, pb.StateTransition_DISTRACTED},
+	eventStartHit:         {idle, pb.StateTransition_HITTING},
+	eventHitDone:          {[]pb.StateTransition_State{pb.StateTransition_HITTING}, pb.StateTransition_LOOT_READY},
+	eventPhaseFailed:      {[]pb.StateTransition_State{pb.StateTransition_DISTRACTING, pb.StateTransition_HITTING}, pb.StateTransition_FAILED},
+	eventAbort:            {[]pb.StateTransition_State{pb.StateTransition_DISTRACTING, pb.StateTransition_HITTING}, pb.StateTransition_FAILED},
+	eventRetrieveLoot:     {[]pb.StateTransition_State{pb.StateTransition_LOOT_READY}, pb.StateTransition_LOOT_READY},
+	eventPaid:             {[]pb.StateTransition_State{pb.StateTransition_DISTRACTED, pb.StateTransition_LOOT_READY}, pb.StateTransition_PAID},
+}
+
+// transition moves phaseState through the event and saves it, or returns
+// FailedPrecondition if the event is not allowed in the current state.
+// phaseState.mu must be held.
+func transition(event string) error {
+	e := stateMachine[event]
+	from := phaseState.state
+	for _, state := range e.from {
+		if state != from {
+			continue
+		}
+		if e.to == from {
+			return nil
+		}
+		log.Printf("State %s -> %s (%s)", from, e.to, event)
+		phaseState.state = e.to
+		phaseState.history = append(phaseState.history, &pb.StateTransition{
+			From:  from,
+			To:    e.to,
+			Event: event,
+			AtMs:  time.Now().UnixMilli(),
+		})
+		if len(phaseState.history) > maxTransitions {
+			phaseState.history = phaseState.history[len(phaseState.history)-maxTransitions:]
+		}
+		saveState()
+		return nil
+	}
+	return status.Errorf(codes.FailedPrecondition, "cannot %s while %s", event, from)
+}
+
+// advance is transition for the events the operator triggers itself, which
+// are only refused if the phase was ended from outside in the meantime.
+func advance(event string) {
+	if err := transition(event); err != nil {
+		log.Printf("Ignoring event: %v", err)
+	}
+}
+
+// phaseStatus is the phase status Michael polls for, derived from the state.
+func phaseStatus(state pb.StateTransition_State) pb.PhaseStatus_Status {
+	switch state {
+	case pb.StateTransition_DISTRACTING, pb.StateTransition_HITTING:
+		return pb.PhaseStatus_IN_PROGESS
+	case pb.StateTransition_DISTRACTED, pb.StateTransition_LOOT_READY, pb.StateTransition_PAID:
+		return pb.PhaseStatus_SUCCESS
+	case pb.StateTransition_FAILED:
+		return pb.PhaseStatus_FAILURE
+	default:
+		return pb.PhaseStatus_AWAITING_ORDERS
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "gateway/proto"
+)
+
+const (
+	defaultHost    = "192.168.1.6"
+	defaultPort    = "8080"
+	requestTimeout = 10 * time.Second
+	// shutdownTimeout is longer than requestTimeout so running requests
+	// can finish.
+	shutdownTimeout = 15 * time.Second
+	maxBodyBytes    = 1 << 20
+)
+
+// openAPI is the OpenAPI document protoc-gen-openapiv2 generates from the
+// annotations in heist.proto.
+//
+//go:embed proto/heist.swagger.json
+var openAPI []byte
+
+// withLimits bounds the body and the duration of every request.
+func withLimits(h http.Handler) http.Handler {
+	return http.MaxBytesHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}), maxBodyBytes)
+}
+
+// logErrors logs the failed calls before answering them as grpc-gateway does,
+// with the HTTP status matching the gRPC code.
+func logErrors(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func main() {
+	lesterHost := getenv("LESTER_HOST", defaultHost)
+	port := getenv("GATEWAY_PORT", defaultPort)
+	log.Printf("Using lester at %s, the operators come from its crew", lesterHost)
+
+	lesterConn, err := grpc.Dial(lesterHost+":50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Could not connect to lester: %v", err)
+	}
+	defer lesterConn.Close()
+	lester := pb.NewLesterServiceClient(lesterConn)
+	operators := newOperatorProxy(lester)
+	defer operators.Close()
+
+	mux := runtime.NewServeMux(runtime.WithErrorHandler(logErrors))
+	if err := pb.RegisterLesterServiceHandlerClient(context.Background(), mux, lester); err != nil {
+		log.Fatalf("Could not register the lester endpoints: %v", err)
+	}
+	if err := pb.RegisterOperatorServiceHandlerServer(context.Background(), mux, operators); err != nil {
+		log.Fatalf("Could not register the operator endpoints: %v", err)
+	}
+	err = mux.HandlePath(http.MethodGet, "/openapi.json", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+	if err != nil {
+		log.Fatalf("Could not serve the OpenAPI document: %v", err)
+	}
+
+	server := &http.Server{Addr: ":" + port, Handler: withLimits(mux)}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		log.Printf("Shutting down, waiting up to %s for running requests", shutdownTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Requests still running after %s, closing them: %v", shutdownTimeout, err)
+			server.Close()
+		}
+	}()
+
+	log.Printf("Gateway listening on :%s", port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("Gateway stopped: %v", err)
+	}
+	<-drained
+	log.Printf("Gateway stopped")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "gateway/proto"
+)
+
+// operatorProxy serves the operator endpoints, forwarding each call to the
+// operator named in the path. Operators are looked up in Lester's crew on
+// every call, so the gateway follows whoever registers or moves.
+type operatorProxy struct {
+	pb.UnimplementedOperatorServiceServer
+	lester pb.LesterServiceClient
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn // by address
+}
+
+func newOperatorProxy(lester pb.LesterServiceClient) *operatorProxy {
+	return &operatorProxy{lester: lester, conns: make(map[string]*grpc.ClientConn)}
+}
+
+// operator resolves the {operator} path segment, ignoring case.
+func (p *operatorProxy) operator(ctx context.Context, name string) (pb.OperatorServiceClient, error) {
+	crew, err := p.lester.ListCrew(ctx, &pb.Empty{})
+	if err != nil {
+		log.Printf("Could not get the crew from lester: %v", err)
+		return nil, err
+	}
+	for _, m := range crew.Members {
+		reg := m.Registration
+		if !strings.EqualFold(reg.Name, name) {
+			continue
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		conn, ok := p.conns[reg.Address]
+		if !ok {
+			conn, err = grpc.Dial(reg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return nil, status.Errorf(codes.Unavailable, "could not connect to %s at %s: %v", reg.Name, reg.Address, err)
+			}
+			p.conns[reg.Address] = conn
+		}
+		return pb.NewOperatorServiceClient(conn), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown operator %q", name)
+}
+
+// Close closes the connections to the operators.
+func (p *operatorProxy) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, addr)
+	}
+}
+
+func (p *operatorProxy) CheckDistractionStatus(ctx context.Context, req *pb.OperatorRequest) (*pb.PhaseStatus, error) {
+	oc, err := p.operator(ctx, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+	return oc.CheckDistractionStatus(ctx, req)
+}
+
+func (p *operatorProxy) RetrieveLoot(ctx context.Context, req *pb.OperatorRequest) (*pb.LootDetails, error) {
+	oc, err := p.operator(ctx, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+	return oc.RetrieveLoot(ctx, req)
+}
+
+func (p *operatorProxy) ConfirmCut(ctx context.Context, req *pb.CutDetails) (*pb.Ack, error) {
+	oc, err := p.operator(ctx, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+	return oc.ConfirmCut(ctx, req)
+}
+
+func (p *operatorProxy) PhaseHistory(ctx context.Context, req *pb.OperatorRequest) (*pb.StateHistory, error) {
+	oc, err := p.operator(ctx, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+	return oc.PhaseHistory(ctx, req)
+}
+
+func (p *operatorProxy) AbortPhase(ctx context.Context, req *pb.AbortRequest) (*pb.PhaseStatus, error) {
+	oc, err := p.operator(ctx, req.Operator)
+	if err != nil {
+		return nil, err
+	}
+	return oc.AbortPhase(ctx, req)
+}
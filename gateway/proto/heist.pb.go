@@ -0,0 +1,2335 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: proto/heist.proto
+
+package proto
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PhaseStatus_Status int32
+
+const (
+	PhaseStatus_IN_PROGESS      PhaseStatus_Status = 0
+	PhaseStatus_SUCCESS         PhaseStatus_Status = 1
+	PhaseStatus_FAILURE         PhaseStatus_Status = 2
+	PhaseStatus_AWAITING_ORDERS PhaseStatus_Status = 3
+)
+
+// Enum value maps for PhaseStatus_Status.
+var (
+	PhaseStatus_Status_name = map[int32]string{
+		0: "IN_PROGESS",
+		1: "SUCCESS",
+		2: "FAILURE",
+		3: "AWAITING_ORDERS",
+	}
+	PhaseStatus_Status_value = map[string]int32{
+		"IN_PROGESS":      0,
+		"SUCCESS":         1,
+		"FAILURE":         2,
+		"AWAITING_ORDERS": 3,
+	}
+)
+
+func (x PhaseStatus_Status) Enum() *PhaseStatus_Status {
+	p := new(PhaseStatus_Status)
+	*p = x
+	return p
+}
+
+func (x PhaseStatus_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PhaseStatus_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_heist_proto_enumTypes[0].Descriptor()
+}
+
+func (PhaseStatus_Status) Type() protoreflect.EnumType {
+	return &file_proto_heist_proto_enumTypes[0]
+}
+
+func (x PhaseStatus_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PhaseStatus_Status.Descriptor instead.
+func (PhaseStatus_Status) EnumDescriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type NotificationCommand_Command int32
+
+const (
+	NotificationCommand_START NotificationCommand_Command = 0
+	NotificationCommand_STOP  NotificationCommand_Command = 1
+)
+
+// Enum value maps for NotificationCommand_Command.
+var (
+	NotificationCommand_Command_name = map[int32]string{
+		0: "START",
+		1: "STOP",
+	}
+	NotificationCommand_Command_value = map[string]int32{
+		"START": 0,
+		"STOP":  1,
+	}
+)
+
+func (x NotificationCommand_Command) Enum() *NotificationCommand_Command {
+	p := new(NotificationCommand_Command)
+	*p = x
+	return p
+}
+
+func (x NotificationCommand_Command) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationCommand_Command) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_heist_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationCommand_Command) Type() protoreflect.EnumType {
+	return &file_proto_heist_proto_enumTypes[1]
+}
+
+func (x NotificationCommand_Command) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationCommand_Command.Descriptor instead.
+func (NotificationCommand_Command) EnumDescriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type NotificationCommand_Model int32
+
+const (
+	NotificationCommand_FIXED   NotificationCommand_Model = 0
+	NotificationCommand_POISSON NotificationCommand_Model = 1
+	NotificationCommand_HEAT    NotificationCommand_Model = 2
+	NotificationCommand_EVENTS  NotificationCommand_Model = 3
+)
+
+// Enum value maps for NotificationCommand_Model.
+var (
+	NotificationCommand_Model_name = map[int32]string{
+		0: "FIXED",
+		1: "POISSON",
+		2: "HEAT",
+		3: "EVENTS",
+	}
+	NotificationCommand_Model_value = map[string]int32{
+		"FIXED":   0,
+		"POISSON": 1,
+		"HEAT":    2,
+		"EVENTS":  3,
+	}
+)
+
+func (x NotificationCommand_Model) Enum() *NotificationCommand_Model {
+	p := new(NotificationCommand_Model)
+	*p = x
+	return p
+}
+
+func (x NotificationCommand_Model) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationCommand_Model) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_heist_proto_enumTypes[2].Descriptor()
+}
+
+func (NotificationCommand_Model) Type() protoreflect.EnumType {
+	return &file_proto_heist_proto_enumTypes[2]
+}
+
+func (x NotificationCommand_Model) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationCommand_Model.Descriptor instead.
+func (NotificationCommand_Model) EnumDescriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{8, 1}
+}
+
+type StateTransition_State int32
+
+const (
+	StateTransition_AWAITING_ORDERS StateTransition_State = 0
+	StateTransition_DISTRACTING     StateTransition_State = 1
+	StateTransition_DISTRACTED      StateTransition_State = 2
+	StateTransition_HITTING         StateTransition_State = 3
+	StateTransition_LOOT_READY      StateTransition_State = 4
+	StateTransition_PAID            StateTransition_State = 5
+	StateTransition_FAILED          StateTransition_State = 6
+)
+
+// Enum value maps for StateTransition_State.
+var (
+	StateTransition_State_name = map[int32]string{
+		0: "AWAITING_ORDERS",
+		1: "DISTRACTING",
+		2: "DISTRACTED",
+		3: "HITTING",
+		4: "LOOT_READY",
+		5: "PAID",
+		6: "FAILED",
+	}
+	StateTransition_State_value = map[string]int32{
+		"AWAITING_ORDERS": 0,
+		"DISTRACTING":     1,
+		"DISTRACTED":      2,
+		"HITTING":         3,
+		"LOOT_READY":      4,
+		"PAID":            5,
+		"FAILED":          6,
+	}
+)
+
+func (x StateTransition_State) Enum() *StateTransition_State {
+	p := new(StateTransition_State)
+	*p = x
+	return p
+}
+
+func (x StateTransition_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StateTransition_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_heist_proto_enumTypes[3].Descriptor()
+}
+
+func (StateTransition_State) Type() protoreflect.EnumType {
+	return &file_proto_heist_proto_enumTypes[3]
+}
+
+func (x StateTransition_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StateTransition_State.Descriptor instead.
+func (StateTransition_State) EnumDescriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{24, 0}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_proto_heist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{0}
+}
+
+type HeistOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loot          int32                  `protobuf:"varint,1,opt,name=loot,proto3" json:"loot,omitempty"`
+	PoliceRisk    int32                  `protobuf:"varint,4,opt,name=police_risk,json=policeRisk,proto3" json:"police_risk,omitempty"`
+	Scenario      string                 `protobuf:"bytes,5,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	Target        string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	OfferId       string                 `protobuf:"bytes,7,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	ExpiresMs     int64                  `protobuf:"varint,8,opt,name=expires_ms,json=expiresMs,proto3" json:"expires_ms,omitempty"`
+	Success       map[string]int32       `protobuf:"bytes,9,rep,name=success,proto3" json:"success,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeistOffer) Reset() {
+	*x = HeistOffer{}
+	mi := &file_proto_heist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeistOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeistOffer) ProtoMessage() {}
+
+func (x *HeistOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeistOffer.ProtoReflect.Descriptor instead.
+func (*HeistOffer) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HeistOffer) GetLoot() int32 {
+	if x != nil {
+		return x.Loot
+	}
+	return 0
+}
+
+func (x *HeistOffer) GetPoliceRisk() int32 {
+	if x != nil {
+		return x.PoliceRisk
+	}
+	return 0
+}
+
+func (x *HeistOffer) GetScenario() string {
+	if x != nil {
+		return x.Scenario
+	}
+	return ""
+}
+
+func (x *HeistOffer) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *HeistOffer) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *HeistOffer) GetExpiresMs() int64 {
+	if x != nil {
+		return x.ExpiresMs
+	}
+	return 0
+}
+
+func (x *HeistOffer) GetSuccess() map[string]int32 {
+	if x != nil {
+		return x.Success
+	}
+	return nil
+}
+
+type OfferBoard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offers        []*HeistOffer          `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferBoard) Reset() {
+	*x = OfferBoard{}
+	mi := &file_proto_heist_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferBoard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferBoard) ProtoMessage() {}
+
+func (x *OfferBoard) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferBoard.ProtoReflect.Descriptor instead.
+func (*OfferBoard) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OfferBoard) GetOffers() []*HeistOffer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type Decision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decision) Reset() {
+	*x = Decision{}
+	mi := &file_proto_heist_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decision) ProtoMessage() {}
+
+func (x *Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decision.ProtoReflect.Descriptor instead.
+func (*Decision) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Decision) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *Decision) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+type BasicMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BasicMessage) Reset() {
+	*x = BasicMessage{}
+	mi := &file_proto_heist_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BasicMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BasicMessage) ProtoMessage() {}
+
+func (x *BasicMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BasicMessage.ProtoReflect.Descriptor instead.
+func (*BasicMessage) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BasicMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PhaseResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseResult) Reset() {
+	*x = PhaseResult{}
+	mi := &file_proto_heist_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseResult) ProtoMessage() {}
+
+func (x *PhaseResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseResult.ProtoReflect.Descriptor instead.
+func (*PhaseResult) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PhaseResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type PhaseStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        PhaseStatus_Status     `protobuf:"varint,1,opt,name=status,proto3,enum=heist.PhaseStatus_Status" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ExtraMoney    int32                  `protobuf:"varint,3,opt,name=extraMoney,proto3" json:"extraMoney,omitempty"`
+	TotalLoot     int32                  `protobuf:"varint,4,opt,name=totalLoot,proto3" json:"totalLoot,omitempty"`
+	Turn          int32                  `protobuf:"varint,5,opt,name=turn,proto3" json:"turn,omitempty"`
+	TurnsNeeded   int32                  `protobuf:"varint,6,opt,name=turns_needed,json=turnsNeeded,proto3" json:"turns_needed,omitempty"`
+	Stars         int32                  `protobuf:"varint,7,opt,name=stars,proto3" json:"stars,omitempty"`
+	AbilityActive bool                   `protobuf:"varint,8,opt,name=ability_active,json=abilityActive,proto3" json:"ability_active,omitempty"`
+	State         StateTransition_State  `protobuf:"varint,9,opt,name=state,proto3,enum=heist.StateTransition_State" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseStatus) Reset() {
+	*x = PhaseStatus{}
+	mi := &file_proto_heist_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseStatus) ProtoMessage() {}
+
+func (x *PhaseStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseStatus.ProtoReflect.Descriptor instead.
+func (*PhaseStatus) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PhaseStatus) GetStatus() PhaseStatus_Status {
+	if x != nil {
+		return x.Status
+	}
+	return PhaseStatus_IN_PROGESS
+}
+
+func (x *PhaseStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PhaseStatus) GetExtraMoney() int32 {
+	if x != nil {
+		return x.ExtraMoney
+	}
+	return 0
+}
+
+func (x *PhaseStatus) GetTotalLoot() int32 {
+	if x != nil {
+		return x.TotalLoot
+	}
+	return 0
+}
+
+func (x *PhaseStatus) GetTurn() int32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *PhaseStatus) GetTurnsNeeded() int32 {
+	if x != nil {
+		return x.TurnsNeeded
+	}
+	return 0
+}
+
+func (x *PhaseStatus) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *PhaseStatus) GetAbilityActive() bool {
+	if x != nil {
+		return x.AbilityActive
+	}
+	return false
+}
+
+func (x *PhaseStatus) GetState() StateTransition_State {
+	if x != nil {
+		return x.State
+	}
+	return StateTransition_AWAITING_ORDERS
+}
+
+type DistractionDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TurnsNeeded   int32                  `protobuf:"varint,1,opt,name=turns_needed,json=turnsNeeded,proto3" json:"turns_needed,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DistractionDetails) Reset() {
+	*x = DistractionDetails{}
+	mi := &file_proto_heist_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DistractionDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DistractionDetails) ProtoMessage() {}
+
+func (x *DistractionDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DistractionDetails.ProtoReflect.Descriptor instead.
+func (*DistractionDetails) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DistractionDetails) GetTurnsNeeded() int32 {
+	if x != nil {
+		return x.TurnsNeeded
+	}
+	return 0
+}
+
+func (x *DistractionDetails) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type NotificationCommand struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Command       NotificationCommand_Command `protobuf:"varint,1,opt,name=command,proto3,enum=heist.NotificationCommand_Command" json:"command,omitempty"`
+	Frequency     int32                       `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	HeistId       string                      `protobuf:"bytes,3,opt,name=heist_id,json=heistId,proto3" json:"heist_id,omitempty"`
+	Model         NotificationCommand_Model   `protobuf:"varint,4,opt,name=model,proto3,enum=heist.NotificationCommand_Model" json:"model,omitempty"`
+	PoliceRisk    int32                       `protobuf:"varint,5,opt,name=police_risk,json=policeRisk,proto3" json:"police_risk,omitempty"`
+	Seed          int64                       `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`
+	SessionId     string                      `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Stars         int32                       `protobuf:"varint,8,opt,name=stars,proto3" json:"stars,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationCommand) Reset() {
+	*x = NotificationCommand{}
+	mi := &file_proto_heist_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationCommand) ProtoMessage() {}
+
+func (x *NotificationCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationCommand.ProtoReflect.Descriptor instead.
+func (*NotificationCommand) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NotificationCommand) GetCommand() NotificationCommand_Command {
+	if x != nil {
+		return x.Command
+	}
+	return NotificationCommand_START
+}
+
+func (x *NotificationCommand) GetFrequency() int32 {
+	if x != nil {
+		return x.Frequency
+	}
+	return 0
+}
+
+func (x *NotificationCommand) GetHeistId() string {
+	if x != nil {
+		return x.HeistId
+	}
+	return ""
+}
+
+func (x *NotificationCommand) GetModel() NotificationCommand_Model {
+	if x != nil {
+		return x.Model
+	}
+	return NotificationCommand_FIXED
+}
+
+func (x *NotificationCommand) GetPoliceRisk() int32 {
+	if x != nil {
+		return x.PoliceRisk
+	}
+	return 0
+}
+
+func (x *NotificationCommand) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *NotificationCommand) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *NotificationCommand) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+type NotificationSession struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	SessionId     string                    `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	HeistId       string                    `protobuf:"bytes,2,opt,name=heist_id,json=heistId,proto3" json:"heist_id,omitempty"`
+	Model         NotificationCommand_Model `protobuf:"varint,3,opt,name=model,proto3,enum=heist.NotificationCommand_Model" json:"model,omitempty"`
+	Stars         int32                     `protobuf:"varint,4,opt,name=stars,proto3" json:"stars,omitempty"`
+	StartedMs     int64                     `protobuf:"varint,5,opt,name=started_ms,json=startedMs,proto3" json:"started_ms,omitempty"`
+	ExpiresMs     int64                     `protobuf:"varint,6,opt,name=expires_ms,json=expiresMs,proto3" json:"expires_ms,omitempty"`
+	HeartbeatMs   int64                     `protobuf:"varint,7,opt,name=heartbeat_ms,json=heartbeatMs,proto3" json:"heartbeat_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSession) Reset() {
+	*x = NotificationSession{}
+	mi := &file_proto_heist_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSession) ProtoMessage() {}
+
+func (x *NotificationSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSession.ProtoReflect.Descriptor instead.
+func (*NotificationSession) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NotificationSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *NotificationSession) GetHeistId() string {
+	if x != nil {
+		return x.HeistId
+	}
+	return ""
+}
+
+func (x *NotificationSession) GetModel() NotificationCommand_Model {
+	if x != nil {
+		return x.Model
+	}
+	return NotificationCommand_FIXED
+}
+
+func (x *NotificationSession) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *NotificationSession) GetStartedMs() int64 {
+	if x != nil {
+		return x.StartedMs
+	}
+	return 0
+}
+
+func (x *NotificationSession) GetExpiresMs() int64 {
+	if x != nil {
+		return x.ExpiresMs
+	}
+	return 0
+}
+
+func (x *NotificationSession) GetHeartbeatMs() int64 {
+	if x != nil {
+		return x.HeartbeatMs
+	}
+	return 0
+}
+
+type SessionHeartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionHeartbeat) Reset() {
+	*x = SessionHeartbeat{}
+	mi := &file_proto_heist_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionHeartbeat) ProtoMessage() {}
+
+func (x *SessionHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionHeartbeat.ProtoReflect.Descriptor instead.
+func (*SessionHeartbeat) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SessionHeartbeat) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type NotificationSessions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*NotificationSession `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSessions) Reset() {
+	*x = NotificationSessions{}
+	mi := &file_proto_heist_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSessions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSessions) ProtoMessage() {}
+
+func (x *NotificationSessions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSessions.ProtoReflect.Descriptor instead.
+func (*NotificationSessions) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NotificationSessions) GetSessions() []*NotificationSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type StarUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HeistId       string                 `protobuf:"bytes,1,opt,name=heist_id,json=heistId,proto3" json:"heist_id,omitempty"`
+	Sequence      int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Stars         int32                  `protobuf:"varint,3,opt,name=stars,proto3" json:"stars,omitempty"`
+	TimestampMs   int64                  `protobuf:"varint,4,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Final         bool                   `protobuf:"varint,6,opt,name=final,proto3" json:"final,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StarUpdate) Reset() {
+	*x = StarUpdate{}
+	mi := &file_proto_heist_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StarUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StarUpdate) ProtoMessage() {}
+
+func (x *StarUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StarUpdate.ProtoReflect.Descriptor instead.
+func (*StarUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StarUpdate) GetHeistId() string {
+	if x != nil {
+		return x.HeistId
+	}
+	return ""
+}
+
+func (x *StarUpdate) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StarUpdate) GetStars() int32 {
+	if x != nil {
+		return x.Stars
+	}
+	return 0
+}
+
+func (x *StarUpdate) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *StarUpdate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StarUpdate) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type HitDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TurnsNeeded   int32                  `protobuf:"varint,1,opt,name=turns_needed,json=turnsNeeded,proto3" json:"turns_needed,omitempty"`
+	Loot          int32                  `protobuf:"varint,2,opt,name=loot,proto3" json:"loot,omitempty"`
+	HeistId       string                 `protobuf:"bytes,3,opt,name=heist_id,json=heistId,proto3" json:"heist_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HitDetails) Reset() {
+	*x = HitDetails{}
+	mi := &file_proto_heist_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HitDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HitDetails) ProtoMessage() {}
+
+func (x *HitDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HitDetails.ProtoReflect.Descriptor instead.
+func (*HitDetails) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HitDetails) GetTurnsNeeded() int32 {
+	if x != nil {
+		return x.TurnsNeeded
+	}
+	return 0
+}
+
+func (x *HitDetails) GetLoot() int32 {
+	if x != nil {
+		return x.Loot
+	}
+	return 0
+}
+
+func (x *HitDetails) GetHeistId() string {
+	if x != nil {
+		return x.HeistId
+	}
+	return ""
+}
+
+func (x *HitDetails) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type LootDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loot          int32                  `protobuf:"varint,1,opt,name=loot,proto3" json:"loot,omitempty"`
+	ExtraMoney    int32                  `protobuf:"varint,2,opt,name=extra_money,json=extraMoney,proto3" json:"extra_money,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LootDetails) Reset() {
+	*x = LootDetails{}
+	mi := &file_proto_heist_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LootDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LootDetails) ProtoMessage() {}
+
+func (x *LootDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LootDetails.ProtoReflect.Descriptor instead.
+func (*LootDetails) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LootDetails) GetLoot() int32 {
+	if x != nil {
+		return x.Loot
+	}
+	return 0
+}
+
+func (x *LootDetails) GetExtraMoney() int32 {
+	if x != nil {
+		return x.ExtraMoney
+	}
+	return 0
+}
+
+type CutDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loot          int32                  `protobuf:"varint,1,opt,name=loot,proto3" json:"loot,omitempty"`
+	ExtraMoeny    int32                  `protobuf:"varint,2,opt,name=extra_moeny,json=extraMoeny,proto3" json:"extra_moeny,omitempty"`
+	ReceivedCut   int32                  `protobuf:"varint,3,opt,name=received_cut,json=receivedCut,proto3" json:"received_cut,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CrewSize      int32                  `protobuf:"varint,5,opt,name=crew_size,json=crewSize,proto3" json:"crew_size,omitempty"`
+	AuditHead     *AuditHead             `protobuf:"bytes,6,opt,name=audit_head,json=auditHead,proto3" json:"audit_head,omitempty"`
+	Operator      string                 `protobuf:"bytes,7,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CutDetails) Reset() {
+	*x = CutDetails{}
+	mi := &file_proto_heist_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CutDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CutDetails) ProtoMessage() {}
+
+func (x *CutDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CutDetails.ProtoReflect.Descriptor instead.
+func (*CutDetails) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CutDetails) GetLoot() int32 {
+	if x != nil {
+		return x.Loot
+	}
+	return 0
+}
+
+func (x *CutDetails) GetExtraMoeny() int32 {
+	if x != nil {
+		return x.ExtraMoeny
+	}
+	return 0
+}
+
+func (x *CutDetails) GetReceivedCut() int32 {
+	if x != nil {
+		return x.ReceivedCut
+	}
+	return 0
+}
+
+func (x *CutDetails) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CutDetails) GetCrewSize() int32 {
+	if x != nil {
+		return x.CrewSize
+	}
+	return 0
+}
+
+func (x *CutDetails) GetAuditHead() *AuditHead {
+	if x != nil {
+		return x.AuditHead
+	}
+	return nil
+}
+
+func (x *CutDetails) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type AuditHead struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	SignedBy      string                 `protobuf:"bytes,3,opt,name=signed_by,json=signedBy,proto3" json:"signed_by,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditHead) Reset() {
+	*x = AuditHead{}
+	mi := &file_proto_heist_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditHead) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditHead) ProtoMessage() {}
+
+func (x *AuditHead) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditHead.ProtoReflect.Descriptor instead.
+func (*AuditHead) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AuditHead) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AuditHead) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *AuditHead) GetSignedBy() string {
+	if x != nil {
+		return x.SignedBy
+	}
+	return ""
+}
+
+func (x *AuditHead) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type Ack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Acknowledged  bool                   `protobuf:"varint,1,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SignedBy      string                 `protobuf:"bytes,3,opt,name=signed_by,json=signedBy,proto3" json:"signed_by,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	AuditHead     *AuditHead             `protobuf:"bytes,5,opt,name=audit_head,json=auditHead,proto3" json:"audit_head,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	mi := &file_proto_heist_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Ack) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *Ack) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Ack) GetSignedBy() string {
+	if x != nil {
+		return x.SignedBy
+	}
+	return ""
+}
+
+func (x *Ack) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Ack) GetAuditHead() *AuditHead {
+	if x != nil {
+		return x.AuditHead
+	}
+	return nil
+}
+
+type OperatorProfile struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	FailStars              int32                  `protobuf:"varint,1,opt,name=fail_stars,json=failStars,proto3" json:"fail_stars,omitempty"`
+	AbilityStars           int32                  `protobuf:"varint,2,opt,name=ability_stars,json=abilityStars,proto3" json:"ability_stars,omitempty"`
+	ExtraPerTurn           int32                  `protobuf:"varint,3,opt,name=extra_per_turn,json=extraPerTurn,proto3" json:"extra_per_turn,omitempty"`
+	DistractionFailureOdds int32                  `protobuf:"varint,4,opt,name=distraction_failure_odds,json=distractionFailureOdds,proto3" json:"distraction_failure_odds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OperatorProfile) Reset() {
+	*x = OperatorProfile{}
+	mi := &file_proto_heist_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorProfile) ProtoMessage() {}
+
+func (x *OperatorProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorProfile.ProtoReflect.Descriptor instead.
+func (*OperatorProfile) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OperatorProfile) GetFailStars() int32 {
+	if x != nil {
+		return x.FailStars
+	}
+	return 0
+}
+
+func (x *OperatorProfile) GetAbilityStars() int32 {
+	if x != nil {
+		return x.AbilityStars
+	}
+	return 0
+}
+
+func (x *OperatorProfile) GetExtraPerTurn() int32 {
+	if x != nil {
+		return x.ExtraPerTurn
+	}
+	return 0
+}
+
+func (x *OperatorProfile) GetDistractionFailureOdds() int32 {
+	if x != nil {
+		return x.DistractionFailureOdds
+	}
+	return 0
+}
+
+type OperatorRegistration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Skills        []string               `protobuf:"bytes,3,rep,name=skills,proto3" json:"skills,omitempty"`
+	Profile       *OperatorProfile       `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorRegistration) Reset() {
+	*x = OperatorRegistration{}
+	mi := &file_proto_heist_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorRegistration) ProtoMessage() {}
+
+func (x *OperatorRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorRegistration.ProtoReflect.Descriptor instead.
+func (*OperatorRegistration) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OperatorRegistration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OperatorRegistration) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *OperatorRegistration) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *OperatorRegistration) GetProfile() *OperatorProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type Heartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_proto_heist_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Heartbeat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Heartbeat) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type RegistrationLease struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ExpiresMs     int64                  `protobuf:"varint,2,opt,name=expires_ms,json=expiresMs,proto3" json:"expires_ms,omitempty"`
+	HeartbeatMs   int64                  `protobuf:"varint,3,opt,name=heartbeat_ms,json=heartbeatMs,proto3" json:"heartbeat_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationLease) Reset() {
+	*x = RegistrationLease{}
+	mi := &file_proto_heist_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationLease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationLease) ProtoMessage() {}
+
+func (x *RegistrationLease) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationLease.ProtoReflect.Descriptor instead.
+func (*RegistrationLease) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RegistrationLease) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegistrationLease) GetExpiresMs() int64 {
+	if x != nil {
+		return x.ExpiresMs
+	}
+	return 0
+}
+
+func (x *RegistrationLease) GetHeartbeatMs() int64 {
+	if x != nil {
+		return x.HeartbeatMs
+	}
+	return 0
+}
+
+type CrewMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Registration  *OperatorRegistration  `protobuf:"bytes,1,opt,name=registration,proto3" json:"registration,omitempty"`
+	RegisteredMs  int64                  `protobuf:"varint,2,opt,name=registered_ms,json=registeredMs,proto3" json:"registered_ms,omitempty"`
+	LastSeenMs    int64                  `protobuf:"varint,3,opt,name=last_seen_ms,json=lastSeenMs,proto3" json:"last_seen_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrewMember) Reset() {
+	*x = CrewMember{}
+	mi := &file_proto_heist_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrewMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrewMember) ProtoMessage() {}
+
+func (x *CrewMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrewMember.ProtoReflect.Descriptor instead.
+func (*CrewMember) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CrewMember) GetRegistration() *OperatorRegistration {
+	if x != nil {
+		return x.Registration
+	}
+	return nil
+}
+
+func (x *CrewMember) GetRegisteredMs() int64 {
+	if x != nil {
+		return x.RegisteredMs
+	}
+	return 0
+}
+
+func (x *CrewMember) GetLastSeenMs() int64 {
+	if x != nil {
+		return x.LastSeenMs
+	}
+	return 0
+}
+
+type Crew struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*CrewMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Crew) Reset() {
+	*x = Crew{}
+	mi := &file_proto_heist_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Crew) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Crew) ProtoMessage() {}
+
+func (x *Crew) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Crew.ProtoReflect.Descriptor instead.
+func (*Crew) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Crew) GetMembers() []*CrewMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type StateTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          StateTransition_State  `protobuf:"varint,1,opt,name=from,proto3,enum=heist.StateTransition_State" json:"from,omitempty"`
+	To            StateTransition_State  `protobuf:"varint,2,opt,name=to,proto3,enum=heist.StateTransition_State" json:"to,omitempty"`
+	Event         string                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	AtMs          int64                  `protobuf:"varint,4,opt,name=at_ms,json=atMs,proto3" json:"at_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateTransition) Reset() {
+	*x = StateTransition{}
+	mi := &file_proto_heist_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateTransition) ProtoMessage() {}
+
+func (x *StateTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateTransition.ProtoReflect.Descriptor instead.
+func (*StateTransition) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StateTransition) GetFrom() StateTransition_State {
+	if x != nil {
+		return x.From
+	}
+	return StateTransition_AWAITING_ORDERS
+}
+
+func (x *StateTransition) GetTo() StateTransition_State {
+	if x != nil {
+		return x.To
+	}
+	return StateTransition_AWAITING_ORDERS
+}
+
+func (x *StateTransition) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *StateTransition) GetAtMs() int64 {
+	if x != nil {
+		return x.AtMs
+	}
+	return 0
+}
+
+type AbortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortRequest) Reset() {
+	*x = AbortRequest{}
+	mi := &file_proto_heist_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortRequest) ProtoMessage() {}
+
+func (x *AbortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortRequest.ProtoReflect.Descriptor instead.
+func (*AbortRequest) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AbortRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AbortRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type OperatorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatorRequest) Reset() {
+	*x = OperatorRequest{}
+	mi := &file_proto_heist_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorRequest) ProtoMessage() {}
+
+func (x *OperatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorRequest.ProtoReflect.Descriptor instead.
+func (*OperatorRequest) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OperatorRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type StateHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         StateTransition_State  `protobuf:"varint,1,opt,name=state,proto3,enum=heist.StateTransition_State" json:"state,omitempty"`
+	Transitions   []*StateTransition     `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateHistory) Reset() {
+	*x = StateHistory{}
+	mi := &file_proto_heist_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateHistory) ProtoMessage() {}
+
+func (x *StateHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_heist_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateHistory.ProtoReflect.Descriptor instead.
+func (*StateHistory) Descriptor() ([]byte, []int) {
+	return file_proto_heist_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *StateHistory) GetState() StateTransition_State {
+	if x != nil {
+		return x.State
+	}
+	return StateTransition_AWAITING_ORDERS
+}
+
+func (x *StateHistory) GetTransitions() []*StateTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+var File_proto_heist_proto protoreflect.FileDescriptor
+
+const file_proto_heist_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/heist.proto\x12\x05heist\x1a\x1cgoogle/api/annotations.proto\"\a\n" +
+	"\x05Empty\"\xd3\x02\n" +
+	"\n" +
+	"HeistOffer\x12\x12\n" +
+	"\x04loot\x18\x01 \x01(\x05R\x04loot\x12\x1f\n" +
+	"\vpolice_risk\x18\x04 \x01(\x05R\n" +
+	"policeRisk\x12\x1a\n" +
+	"\bscenario\x18\x05 \x01(\tR\bscenario\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x19\n" +
+	"\boffer_id\x18\a \x01(\tR\aofferId\x12\x1d\n" +
+	"\n" +
+	"expires_ms\x18\b \x01(\x03R\texpiresMs\x128\n" +
+	"\asuccess\x18\t \x03(\v2\x1e.heist.HeistOffer.SuccessEntryR\asuccess\x1a:\n" +
+	"\fSuccessEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01J\x04\b\x02\x10\x03J\x04\b\x03\x10\x04R\x10franklin_successR\x0etrevor_success\"7\n" +
+	"\n" +
+	"OfferBoard\x12)\n" +
+	"\x06offers\x18\x01 \x03(\v2\x11.heist.HeistOfferR\x06offers\"A\n" +
+	"\bDecision\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\"(\n" +
+	"\fBasicMessage\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"'\n" +
+	"\vPhaseResult\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x89\x03\n" +
+	"\vPhaseStatus\x121\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x19.heist.PhaseStatus.StatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
+	"\n" +
+	"extraMoney\x18\x03 \x01(\x05R\n" +
+	"extraMoney\x12\x1c\n" +
+	"\ttotalLoot\x18\x04 \x01(\x05R\ttotalLoot\x12\x12\n" +
+	"\x04turn\x18\x05 \x01(\x05R\x04turn\x12!\n" +
+	"\fturns_needed\x18\x06 \x01(\x05R\vturnsNeeded\x12\x14\n" +
+	"\x05stars\x18\a \x01(\x05R\x05stars\x12%\n" +
+	"\x0eability_active\x18\b \x01(\bR\rabilityActive\x122\n" +
+	"\x05state\x18\t \x01(\x0e2\x1c.heist.StateTransition.StateR\x05state\"G\n" +
+	"\x06Status\x12\x0e\n" +
+	"\n" +
+	"IN_PROGESS\x10\x00\x12\v\n" +
+	"\aSUCCESS\x10\x01\x12\v\n" +
+	"\aFAILURE\x10\x02\x12\x13\n" +
+	"\x0fAWAITING_ORDERS\x10\x03\"V\n" +
+	"\x12DistractionDetails\x12!\n" +
+	"\fturns_needed\x18\x01 \x01(\x05R\vturnsNeeded\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"\x85\x03\n" +
+	"\x13NotificationCommand\x12<\n" +
+	"\acommand\x18\x01 \x01(\x0e2\".heist.NotificationCommand.CommandR\acommand\x12\x1c\n" +
+	"\tfrequency\x18\x02 \x01(\x05R\tfrequency\x12\x19\n" +
+	"\bheist_id\x18\x03 \x01(\tR\aheistId\x126\n" +
+	"\x05model\x18\x04 \x01(\x0e2 .heist.NotificationCommand.ModelR\x05model\x12\x1f\n" +
+	"\vpolice_risk\x18\x05 \x01(\x05R\n" +
+	"policeRisk\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\a \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05stars\x18\b \x01(\x05R\x05stars\"\x1e\n" +
+	"\aCommand\x12\t\n" +
+	"\x05START\x10\x00\x12\b\n" +
+	"\x04STOP\x10\x01\"5\n" +
+	"\x05Model\x12\t\n" +
+	"\x05FIXED\x10\x00\x12\v\n" +
+	"\aPOISSON\x10\x01\x12\b\n" +
+	"\x04HEAT\x10\x02\x12\n" +
+	"\n" +
+	"\x06EVENTS\x10\x03\"\xfe\x01\n" +
+	"\x13NotificationSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
+	"\bheist_id\x18\x02 \x01(\tR\aheistId\x126\n" +
+	"\x05model\x18\x03 \x01(\x0e2 .heist.NotificationCommand.ModelR\x05model\x12\x14\n" +
+	"\x05stars\x18\x04 \x01(\x05R\x05stars\x12\x1d\n" +
+	"\n" +
+	"started_ms\x18\x05 \x01(\x03R\tstartedMs\x12\x1d\n" +
+	"\n" +
+	"expires_ms\x18\x06 \x01(\x03R\texpiresMs\x12!\n" +
+	"\fheartbeat_ms\x18\a \x01(\x03R\vheartbeatMs\"1\n" +
+	"\x10SessionHeartbeat\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"N\n" +
+	"\x14NotificationSessions\x126\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1a.heist.NotificationSessionR\bsessions\"\xaa\x01\n" +
+	"\n" +
+	"StarUpdate\x12\x19\n" +
+	"\bheist_id\x18\x01 \x01(\tR\aheistId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x12\x14\n" +
+	"\x05stars\x18\x03 \x01(\x05R\x05stars\x12!\n" +
+	"\ftimestamp_ms\x18\x04 \x01(\x03R\vtimestampMs\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05final\x18\x06 \x01(\bR\x05final\"}\n" +
+	"\n" +
+	"HitDetails\x12!\n" +
+	"\fturns_needed\x18\x01 \x01(\x05R\vturnsNeeded\x12\x12\n" +
+	"\x04loot\x18\x02 \x01(\x05R\x04loot\x12\x19\n" +
+	"\bheist_id\x18\x03 \x01(\tR\aheistId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"B\n" +
+	"\vLootDetails\x12\x12\n" +
+	"\x04loot\x18\x01 \x01(\x05R\x04loot\x12\x1f\n" +
+	"\vextra_money\x18\x02 \x01(\x05R\n" +
+	"extraMoney\"\xed\x01\n" +
+	"\n" +
+	"CutDetails\x12\x12\n" +
+	"\x04loot\x18\x01 \x01(\x05R\x04loot\x12\x1f\n" +
+	"\vextra_moeny\x18\x02 \x01(\x05R\n" +
+	"extraMoeny\x12!\n" +
+	"\freceived_cut\x18\x03 \x01(\x05R\vreceivedCut\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\x12\x1b\n" +
+	"\tcrew_size\x18\x05 \x01(\x05R\bcrewSize\x12/\n" +
+	"\n" +
+	"audit_head\x18\x06 \x01(\v2\x10.heist.AuditHeadR\tauditHead\x12\x1a\n" +
+	"\boperator\x18\a \x01(\tR\boperator\"p\n" +
+	"\tAuditHead\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x1b\n" +
+	"\tsigned_by\x18\x03 \x01(\tR\bsignedBy\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\"\xaf\x01\n" +
+	"\x03Ack\x12\"\n" +
+	"\facknowledged\x18\x01 \x01(\bR\facknowledged\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tsigned_by\x18\x03 \x01(\tR\bsignedBy\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\x12/\n" +
+	"\n" +
+	"audit_head\x18\x05 \x01(\v2\x10.heist.AuditHeadR\tauditHead\"\xb5\x01\n" +
+	"\x0fOperatorProfile\x12\x1d\n" +
+	"\n" +
+	"fail_stars\x18\x01 \x01(\x05R\tfailStars\x12#\n" +
+	"\rability_stars\x18\x02 \x01(\x05R\fabilityStars\x12$\n" +
+	"\x0eextra_per_turn\x18\x03 \x01(\x05R\fextraPerTurn\x128\n" +
+	"\x18distraction_failure_odds\x18\x04 \x01(\x05R\x16distractionFailureOdds\"\x8e\x01\n" +
+	"\x14OperatorRegistration\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06skills\x18\x03 \x03(\tR\x06skills\x120\n" +
+	"\aprofile\x18\x04 \x01(\v2\x16.heist.OperatorProfileR\aprofile\"9\n" +
+	"\tHeartbeat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"i\n" +
+	"\x11RegistrationLease\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"expires_ms\x18\x02 \x01(\x03R\texpiresMs\x12!\n" +
+	"\fheartbeat_ms\x18\x03 \x01(\x03R\vheartbeatMs\"\x94\x01\n" +
+	"\n" +
+	"CrewMember\x12?\n" +
+	"\fregistration\x18\x01 \x01(\v2\x1b.heist.OperatorRegistrationR\fregistration\x12#\n" +
+	"\rregistered_ms\x18\x02 \x01(\x03R\fregisteredMs\x12 \n" +
+	"\flast_seen_ms\x18\x03 \x01(\x03R\n" +
+	"lastSeenMs\"3\n" +
+	"\x04Crew\x12+\n" +
+	"\amembers\x18\x01 \x03(\v2\x11.heist.CrewMemberR\amembers\"\x8e\x02\n" +
+	"\x0fStateTransition\x120\n" +
+	"\x04from\x18\x01 \x01(\x0e2\x1c.heist.StateTransition.StateR\x04from\x12,\n" +
+	"\x02to\x18\x02 \x01(\x0e2\x1c.heist.StateTransition.StateR\x02to\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12\x13\n" +
+	"\x05at_ms\x18\x04 \x01(\x03R\x04atMs\"p\n" +
+	"\x05State\x12\x13\n" +
+	"\x0fAWAITING_ORDERS\x10\x00\x12\x0f\n" +
+	"\vDISTRACTING\x10\x01\x12\x0e\n" +
+	"\n" +
+	"DISTRACTED\x10\x02\x12\v\n" +
+	"\aHITTING\x10\x03\x12\x0e\n" +
+	"\n" +
+	"LOOT_READY\x10\x04\x12\b\n" +
+	"\x04PAID\x10\x05\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x06\"B\n" +
+	"\fAbortRequest\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\"-\n" +
+	"\x0fOperatorRequest\x12\x1a\n" +
+	"\boperator\x18\x01 \x01(\tR\boperator\"|\n" +
+	"\fStateHistory\x122\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x1c.heist.StateTransition.StateR\x05state\x128\n" +
+	"\vtransitions\x18\x02 \x03(\v2\x16.heist.StateTransitionR\vtransitions2\xcb\x05\n" +
+	"\rLesterService\x12P\n" +
+	"\x11ProposeHeistOffer\x12\f.heist.Empty\x1a\x11.heist.HeistOffer\"\x1a\x82\xd3\xe4\x93\x02\x14\"\x12/v1/offers/propose\x12-\n" +
+	"\n" +
+	"ListOffers\x12\f.heist.Empty\x1a\x11.heist.OfferBoard\x12Y\n" +
+	"\rDecideOnOffer\x12\x0f.heist.Decision\x1a\f.heist.Empty\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/offers/{offer_id}/decision\x12R\n" +
+	"\x18ManageStarsNotifications\x12\x1a.heist.NotificationCommand\x1a\x1a.heist.NotificationSession\x12E\n" +
+	"\x18ListNotificationSessions\x12\f.heist.Empty\x1a\x1b.heist.NotificationSessions\x12H\n" +
+	"\x11RenewStarsSession\x12\x17.heist.SessionHeartbeat\x1a\x1a.heist.NotificationSession\x12F\n" +
+	"\n" +
+	"ConfirmCut\x12\x11.heist.CutDetails\x1a\n" +
+	".heist.Ack\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/lester/cut\x12I\n" +
+	"\x10RegisterOperator\x12\x1b.heist.OperatorRegistration\x1a\x18.heist.RegistrationLease\x12?\n" +
+	"\x11OperatorHeartbeat\x12\x10.heist.Heartbeat\x1a\x18.heist.RegistrationLease\x12%\n" +
+	"\bListCrew\x12\f.heist.Empty\x1a\v.heist.Crew2\xec\x04\n" +
+	"\x0fOperatorService\x12;\n" +
+	"\x10StartDistraction\x12\x19.heist.DistractionDetails\x1a\f.heist.Empty\x12m\n" +
+	"\x16CheckDistractionStatus\x12\x16.heist.OperatorRequest\x1a\x12.heist.PhaseStatus\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/operators/{operator}/status\x12+\n" +
+	"\bStartHit\x12\x11.heist.HitDetails\x1a\f.heist.Empty\x12a\n" +
+	"\fRetrieveLoot\x12\x16.heist.OperatorRequest\x1a\x12.heist.LootDetails\"%\x82\xd3\xe4\x93\x02\x1f\"\x1d/v1/operators/{operator}/loot\x12T\n" +
+	"\n" +
+	"ConfirmCut\x12\x11.heist.CutDetails\x1a\n" +
+	".heist.Ack\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/operators/{operator}/cut\x12e\n" +
+	"\fPhaseHistory\x12\x16.heist.OperatorRequest\x1a\x13.heist.StateHistory\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/operators/{operator}/history\x12`\n" +
+	"\n" +
+	"AbortPhase\x12\x13.heist.AbortRequest\x1a\x12.heist.PhaseStatus\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/operators/{operator}/abortB\bZ\x06/protob\x06proto3"
+
+var (
+	file_proto_heist_proto_rawDescOnce sync.Once
+	file_proto_heist_proto_rawDescData []byte
+)
+
+func file_proto_heist_proto_rawDescGZIP() []byte {
+	file_proto_heist_proto_rawDescOnce.Do(func() {
+		file_proto_heist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_heist_proto_rawDesc), len(file_proto_heist_proto_rawDesc)))
+	})
+	return file_proto_heist_proto_rawDescData
+}
+
+var file_proto_heist_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_heist_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_proto_heist_proto_goTypes = []any{
+	(PhaseStatus_Status)(0),          // 0: heist.PhaseStatus.Status
+	(NotificationCommand_Command)(0), // 1: heist.NotificationCommand.Command
+	(NotificationCommand_Model)(0),   // 2: heist.NotificationCommand.Model
+	(StateTransition_State)(0),       // 3: heist.StateTransition.State
+	(*Empty)(nil),                    // 4: heist.Empty
+	(*HeistOffer)(nil),               // 5: heist.HeistOffer
+	(*OfferBoard)(nil),               // 6: heist.OfferBoard
+	(*Decision)(nil),                 // 7: heist.Decision
+	(*BasicMessage)(nil),             // 8: heist.BasicMessage
+	(*PhaseResult)(nil),              // 9: heist.PhaseResult
+	(*PhaseStatus)(nil),              // 10: heist.PhaseStatus
+	(*DistractionDetails)(nil),       // 11: heist.DistractionDetails
+	(*NotificationCommand)(nil),      // 12: heist.NotificationCommand
+	(*NotificationSession)(nil),      // 13: heist.NotificationSession
+	(*SessionHeartbeat)(nil),         // 14: heist.SessionHeartbeat
+	(*NotificationSessions)(nil),     // 15: heist.NotificationSessions
+	(*StarUpdate)(nil),               // 16: heist.StarUpdate
+	(*HitDetails)(nil),               // 17: heist.HitDetails
+	(*LootDetails)(nil),              // 18: heist.LootDetails
+	(*CutDetails)(nil),               // 19: heist.CutDetails
+	(*AuditHead)(nil),                // 20: heist.AuditHead
+	(*Ack)(nil),                      // 21: heist.Ack
+	(*OperatorProfile)(nil),          // 22: heist.OperatorProfile
+	(*OperatorRegistration)(nil),     // 23: heist.OperatorRegistration
+	(*Heartbeat)(nil),                // 24: heist.Heartbeat
+	(*RegistrationLease)(nil),        // 25: heist.RegistrationLease
+	(*CrewMember)(nil),               // 26: heist.CrewMember
+	(*Crew)(nil),                     // 27: heist.Crew
+	(*StateTransition)(nil),          // 28: heist.StateTransition
+	(*AbortRequest)(nil),             // 29: heist.AbortRequest
+	(*OperatorRequest)(nil),          // 30: heist.OperatorRequest
+	(*StateHistory)(nil),             // 31: heist.StateHistory
+	nil,                              // 32: heist.HeistOffer.SuccessEntry
+}
+var file_proto_heist_proto_depIdxs = []int32{
+	32, // 0: heist.HeistOffer.success:type_name -> heist.HeistOffer.SuccessEntry
+	5,  // 1: heist.OfferBoard.offers:type_name -> heist.HeistOffer
+	0,  // 2: heist.PhaseStatus.status:type_name -> heist.PhaseStatus.Status
+	3,  // 3: heist.PhaseStatus.state:type_name -> heist.StateTransition.State
+	1,  // 4: heist.NotificationCommand.command:type_name -> heist.NotificationCommand.Command
+	2,  // 5: heist.NotificationCommand.model:type_name -> heist.NotificationCommand.Model
+	2,  // 6: heist.NotificationSession.model:type_name -> heist.NotificationCommand.Model
+	13, // 7: heist.NotificationSessions.sessions:type_name -> heist.NotificationSession
+	20, // 8: heist.CutDetails.audit_head:type_name -> heist.AuditHead
+	20, // 9: heist.Ack.audit_head:type_name -> heist.AuditHead
+	22, // 10: heist.OperatorRegistration.profile:type_name -> heist.OperatorProfile
+	23, // 11: heist.CrewMember.registration:type_name -> heist.OperatorRegistration
+	26, // 12: heist.Crew.members:type_name -> heist.CrewMember
+	3,  // 13: heist.StateTransition.from:type_name -> heist.StateTransition.State
+	3,  // 14: heist.StateTransition.to:type_name -> heist.StateTransition.State
+	3,  // 15: heist.StateHistory.state:type_name -> heist.StateTransition.State
+	28, // 16: heist.StateHistory.transitions:type_name -> heist.StateTransition
+	4,  // 17: heist.LesterService.ProposeHeistOffer:input_type -> heist.Empty
+	4,  // 18: heist.LesterService.ListOffers:input_type -> heist.Empty
+	7,  // 19: heist.LesterService.DecideOnOffer:input_type -> heist.Decision
+	12, // 20: heist.LesterService.ManageStarsNotifications:input_type -> heist.NotificationCommand
+	4,  // 21: heist.LesterService.ListNotificationSessions:input_type -> heist.Empty
+	14, // 22: heist.LesterService.RenewStarsSession:input_type -> heist.SessionHeartbeat
+	19, // 23: heist.LesterService.ConfirmCut:input_type -> heist.CutDetails
+	23, // 24: heist.LesterService.RegisterOperator:input_type -> heist.OperatorRegistration
+	24, // 25: heist.LesterService.OperatorHeartbeat:input_type -> heist.Heartbeat
+	4,  // 26: heist.LesterService.ListCrew:input_type -> heist.Empty
+	11, // 27: heist.OperatorService.StartDistraction:input_type -> heist.DistractionDetails
+	30, // 28: heist.OperatorService.CheckDistractionStatus:input_type -> heist.OperatorRequest
+	17, // 29: heist.OperatorService.StartHit:input_type -> heist.HitDetails
+	30, // 30: heist.OperatorService.RetrieveLoot:input_type -> heist.OperatorRequest
+	19, // 31: heist.OperatorService.ConfirmCut:input_type -> heist.CutDetails
+	30, // 32: heist.OperatorService.PhaseHistory:input_type -> heist.OperatorRequest
+	29, // 33: heist.OperatorService.AbortPhase:input_type -> heist.AbortRequest
+	5,  // 34: heist.LesterService.ProposeHeistOffer:output_type -> heist.HeistOffer
+	6,  // 35: heist.LesterService.ListOffers:output_type -> heist.OfferBoard
+	4,  // 36: heist.LesterService.DecideOnOffer:output_type -> heist.Empty
+	13, // 37: heist.LesterService.ManageStarsNotifications:output_type -> heist.NotificationSession
+	15, // 38: heist.LesterService.ListNotificationSessions:output_type -> heist.NotificationSessions
+	13, // 39: heist.LesterService.RenewStarsSession:output_type -> heist.NotificationSession
+	21, // 40: heist.LesterService.ConfirmCut:output_type -> heist.Ack
+	25, // 41: heist.LesterService.RegisterOperator:output_type -> heist.RegistrationLease
+	25, // 42: heist.LesterService.OperatorHeartbeat:output_type -> heist.RegistrationLease
+	27, // 43: heist.LesterService.ListCrew:output_type -> heist.Crew
+	4,  // 44: heist.OperatorService.StartDistraction:output_type -> heist.Empty
+	10, // 45: heist.OperatorService.CheckDistractionStatus:output_type -> heist.PhaseStatus
+	4,  // 46: heist.OperatorService.StartHit:output_type -> heist.Empty
+	18, // 47: heist.OperatorService.RetrieveLoot:output_type -> heist.LootDetails
+	21, // 48: heist.OperatorService.ConfirmCut:output_type -> heist.Ack
+	31, // 49: heist.OperatorService.PhaseHistory:output_type -> heist.StateHistory
+	10, // 50: heist.OperatorService.AbortPhase:output_type -> heist.PhaseStatus
+	34, // [34:51] is the sub-list for method output_type
+	17, // [17:34] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_proto_heist_proto_init() }
+func file_proto_heist_proto_init() {
+	if File_proto_heist_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_heist_proto_rawDesc), len(file_proto_heist_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_proto_heist_proto_goTypes,
+		DependencyIndexes: file_proto_heist_proto_depIdxs,
+		EnumInfos:         file_proto_heist_proto_enumTypes,
+		MessageInfos:      file_proto_heist_proto_msgTypes,
+	}.Build()
+	File_proto_heist_proto = out.File
+	file_proto_heist_proto_goTypes = nil
+	file_proto_heist_proto_depIdxs = nil
+}
@@ -0,0 +1,17 @@
+module heist-sim
+
+go 1.23.0
+
+require (
+	franklin v0.0.0
+	lester v0.0.0
+	michael v0.0.0
+	trevor v0.0.0
+)
+
+replace (
+	franklin => ../franklin
+	lester => ../lester
+	michael => ../michael
+	trevor => ../trevor
+)
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	franklin "franklin/phases"
+	"lester/catalog"
+	"lester/police"
+	"michael/assign"
+	trevor "trevor/phases"
+)
+
+// distraction and hit are the turn-by-turn rules each operator's phases
+// package implements.
+type distraction interface {
+	Turn(turn int32, intn func(int) int) bool
+}
+
+type hit interface {
+	Turn(stars int32) bool
+}
+
+// crewMember wires an operator's phase rules into the simulator, along with
+// the profile Michael plans with.
+type crewMember struct {
+	profile            assign.Profile
+	distractionFailure string
+	hitFailure         string
+	newDistraction     func(turns int32) distraction
+	newHit             func(turns int32) (hit, func() int32)
+}
+
+var crew = map[string]crewMember{
+	franklin.Name: {
+		profile: assign.Profile{
+			Skills:          assign.Phases,
+			DistractionOdds: franklin.DistractionFailureOdds / 100.0,
+			FailStars:       franklin.FailStars,
+			AbilityStars:    franklin.AbilityStars,
+			ExtraPerTurn:    franklin.ExtraPerTurn,
+		},
+		distractionFailure: franklin.DistractionFailureMessage,
+		hitFailure:         franklin.HitFailureMessage,
+		newDistraction:     func(turns int32) distraction { return &franklin.Distraction{TurnsNeeded: turns} },
+		newHit: func(turns int32) (hit, func() int32) {
+			h := &franklin.Hit{TurnsNeeded: turns}
+			return h, func() int32 { return h.ExtraMoney }
+		},
+	},
+	trevor.Name: {
+		profile: assign.Profile{
+			Skills:          assign.Phases,
+			DistractionOdds: trevor.DistractionFailureOdds / 100.0,
+			FailStars:       trevor.FailStars,
+			AbilityStars:    trevor.AbilityStars,
+			ExtraPerTurn:    trevor.ExtraPerTurn,
+		},
+		distractionFailure: trevor.DistractionFailureMessage,
+		hitFailure:         trevor.HitFailureMessage,
+		newDistraction:     func(turns int32) distraction { return &trevor.Distraction{TurnsNeeded: turns} },
+		newHit: func(turns int32) (hit, func() int32) {
+			h := &trevor.Hit{TurnsNeeded: turns}
+			return h, func() int32 { return h.ExtraMoney }
+		},
+	},
+}
+
+var models = map[string]police.Model{
+	"fixed":   police.Fixed,
+	"poisson": police.Poisson,
+	"heat":    police.Heat,
+	"events":  police.Events,
+}
+
+// outcome is the result of one simulated heist.
+type outcome struct {
+	plan    string
+	success bool
+	cause   string
+	take    int32
+	extra   int32
+}
+
+// planner assigns the phases the way Michael does, with the same escalation
+// model as the simulated police.
+type planner struct {
+	*assign.Planner
+	pinned map[string]string
+}
+
+func newPlanner(model police.Model, pinned map[string]string) *planner {
+	profiles := make(map[string]assign.Profile, len(crew))
+	for name, member := range crew {
+		profiles[name] = member.profile
+	}
+	return &planner{Planner: assign.NewPlanner(profiles, model), pinned: pinned}
+}
+
+// plan returns Michael's assignment for the offer. Catalog offers key the
+// success rates by lowercase operator name.
+func (p *planner) plan(offer catalog.Offer) (assign.Assignment, error) {
+	in := assign.Offer{PoliceRisk: offer.PoliceRisk, Success: make(map[string]int32, len(crew))}
+	for name := range crew {
+		if rate, ok := offer.Success[strings.ToLower(name)]; ok {
+			in.Success[name] = rate
+		}
+	}
+	return p.Optimize(in, p.pinned)
+}
+
+// simulate runs one heist the way Michael coordinates it: the phases go to
+// the operators his planner picks, each needing 200 minus their success rate
+// turns.
+func simulate(rng *rand.Rand, offer catalog.Offer, model police.Model, p *planner) outcome {
+	plan, err := p.plan(offer)
+	if err != nil {
+		return outcome{cause: fmt.Sprintf("cannot staff the offer: %v", err)}
+	}
+	distractionBy, hitBy := plan.Operators["distraction"], plan.Operators["hit"]
+	staffed := fmt.Sprintf("distraction by %s, hit by %s", distractionBy, hitBy)
+	distractionSuccess := offer.Success[strings.ToLower(distractionBy)]
+	hitSuccess := offer.Success[strings.ToLower(hitBy)]
+
+	d := crew[distractionBy].newDistraction(200 - distractionSuccess)
+	for turn := int32(1); turn <= 200-distractionSuccess; turn++ {
+		if d.Turn(turn, rng.Intn) {
+			return outcome{plan: staffed, cause: fmt.Sprintf("distraction by %s: %s", distractionBy, crew[distractionBy].distractionFailure)}
+		}
+	}
+
+	h, extra := crew[hitBy].newHit(200 - hitSuccess)
+	escalation := police.New(model, int(100-offer.PoliceRisk), int(offer.PoliceRisk), rng.Int63()|1) // never 0, which would seed from the clock
+	stars := 0
+	for turn := int32(1); turn <= 200-hitSuccess; turn++ {
+		stars, _ = escalation.Turn(stars)
+		if h.Turn(int32(stars)) {
+			return outcome{plan: staffed, cause: fmt.Sprintf("hit by %s: %s", hitBy, crew[hitBy].hitFailure)}
+		}
+	}
+	return outcome{plan: staffed, success: true, take: offer.Loot + extra(), extra: extra()}
+}
+
+// crewName returns the crew member's name as written in the crew, whatever
+// the case it is given in.
+func crewName(name string) string {
+	for member := range crew {
+		if strings.EqualFold(member, name) {
+			return member
+		}
+	}
+	return ""
+}
+
+// percentile returns the p-th percentile of sorted values.
+func percentile(sorted []int32, p float64) int32 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p/100*float64(len(sorted)-1))]
+}
+
+func main() {
+	runs := flag.Int("n", 10000, "number of heists to simulate")
+	scenarioID := flag.String("scenario", "", "draw each offer from this scenario instead of using a fixed offer")
+	scenariosFile := flag.String("scenarios", "../lester/scenarios.json", "scenario catalog")
+	loot := flag.Int("loot", 1000000, "loot of the fixed offer")
+	risk := flag.Int("risk", 50, "police risk of the fixed offer")
+	franklinSuccess := flag.Int("franklin", 60, "Franklin's success rate in the fixed offer")
+	trevorSuccess := flag.Int("trevor", 40, "Trevor's success rate in the fixed offer")
+	modelName := flag.String("model", "fixed", "police escalation model: fixed, poisson, heat or events")
+	seed := flag.Int64("seed", 0, "random seed, 0 picks one from the clock")
+	distraction := flag.String("distraction", "", "pin the operator running the distraction")
+	hit := flag.String("hit", "", "pin the operator running the hit")
+	flag.Parse()
+
+	model, ok := models[strings.ToLower(*modelName)]
+	if !ok {
+		log.Fatalf("Unknown escalation model %q", *modelName)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	pinned := make(map[string]string)
+	for phase, name := range map[string]string{"distraction": *distraction, "hit": *hit} {
+		if name == "" {
+			continue
+		}
+		member := crewName(name)
+		if member == "" {
+			log.Fatalf("Unknown operator %q for the %s", name, phase)
+		}
+		pinned[phase] = member
+	}
+	planner := newPlanner(model, pinned)
+
+	draw := func() catalog.Offer {
+		return catalog.Offer{
+			Loot:       int32(*loot),
+			PoliceRisk: int32(*risk),
+			Success:    map[string]int32{"franklin": int32(*franklinSuccess), "trevor": int32(*trevorSuccess)},
+		}
+	}
+	description := fmt.Sprintf("offer loot $%d, police risk %d, Franklin %d, Trevor %d", *loot, *risk, *franklinSuccess, *trevorSuccess)
+	if *scenarioID != "" {
+		scenarios, err := catalog.Load(*scenariosFile)
+		if err != nil {
+			log.Fatalf("Could not load scenarios: %v", err)
+		}
+		sc, ok := catalog.Find(scenarios, *scenarioID)
+		if !ok {
+			log.Fatalf("Unknown scenario %q", *scenarioID)
+		}
+		draw = func() catalog.Offer { return sc.Draw(rng) }
+		description = fmt.Sprintf("scenario %s (%s)", sc.ID, sc.Name)
+	}
+
+	successes := 0
+	var extraTotal int64
+	takes := make([]int32, 0, *runs)
+	causes := make(map[string]int)
+	plans := make(map[string]int)
+	for i := 0; i < *runs; i++ {
+		o := simulate(rng, draw(), model, planner)
+		takes = append(takes, o.take)
+		if o.plan != "" {
+			plans[o.plan]++
+		}
+		if o.success {
+			successes++
+			extraTotal += int64(o.extra)
+		} else {
+			causes[o.cause]++
+		}
+	}
+	sort.Slice(takes, func(i, j int) bool { return takes[i] < takes[j] })
+
+	w := os.Stdout
+	fmt.Fprintf(w, "Simulated %d heists for %s with the %s model (seed %d)\n", *runs, description, *modelName, *seed)
+	fmt.Fprintf(w, "Success probability : %.2f%%\n", 100*float64(successes)/float64(max(*runs, 1)))
+	if successes > 0 {
+		fmt.Fprintf(w, "Average extra money : $%d\n", extraTotal/int64(successes))
+	}
+	fmt.Fprintln(w, "Take percentiles (failed heists take $0):")
+	for _, p := range []float64{5, 25, 50, 75, 95} {
+		fmt.Fprintf(w, "  p%-2.0f : $%d\n", p, percentile(takes, p))
+	}
+
+	type causeCount struct {
+		cause string
+		count int
+	}
+	byCount := func(counts map[string]int) []causeCount {
+		var sorted []causeCount
+		for cause, count := range counts {
+			sorted = append(sorted, causeCount{cause, count})
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			if sorted[i].count != sorted[j].count {
+				return sorted[i].count > sorted[j].count
+			}
+			return sorted[i].cause < sorted[j].cause
+		})
+		return sorted
+	}
+	fmt.Fprintln(w, "Michael's assignments:")
+	for _, p := range byCount(plans) {
+		fmt.Fprintf(w, "  %5.2f%% %s\n", 100*float64(p.count)/float64(*runs), p.cause)
+	}
+	if failures := byCount(causes); len(failures) > 0 {
+		fmt.Fprintln(w, "Failure causes:")
+		for _, f := range failures {
+			fmt.Fprintf(w, "  %5.2f%% %s\n", 100*float64(f.count)/float64(*runs), f.cause)
+		}
+	}
+}
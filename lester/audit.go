@@ -0,0 +1,50 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+
+	pb "lester/proto"
+)
+
+const auditName = "Lester"
+
+// auditKey is Lester's HMAC key. Cut confirmations are signed with it, so
+// Michael's audit log can prove what Lester answered.
+var auditKey = []byte(os.Getenv("AUDIT_KEY"))
+
+func init() {
+	if len(auditKey) == 0 {
+		log.Printf("AUDIT_KEY is not set, cut confirmations will not be signed")
+	}
+}
+
+// signCut signs the answer to a cut proposal. Michael verifies the signature
+// over the same fields when it checks the audit log.
+func signCut(details *pb.CutDetails, ack *pb.Ack) {
+	if len(auditKey) == 0 {
+		return
+	}
+	ack.SignedBy = auditName
+	mac := hmac.New(sha256.New, auditKey)
+	fmt.Fprintf(mac, "cut|%s|%s|%d|%d|%d|%d|%t|%s", ack.SignedBy, details.RequestId,
+		details.Loot, details.ExtraMoeny, details.ReceivedCut, details.CrewSize, ack.Acknowledged, ack.Message)
+	ack.Signature = mac.Sum(nil)
+
+	if head := details.AuditHead; head != nil {
+		ack.AuditHead = signHead(head)
+	}
+}
+
+// signHead signs the head of Michael's audit log, the number of entries it
+// had and the hash of the last one, when he proposed Lester's cut. Michael
+// keeps it with the heist, so entries removed from the end of the log no
+// longer match it.
+func signHead(head *pb.AuditHead) *pb.AuditHead {
+	mac := hmac.New(sha256.New, auditKey)
+	fmt.Fprintf(mac, "head|%s|%d|%s", auditName, head.Count, head.Hash)
+	return &pb.AuditHead{Count: head.Count, Hash: head.Hash, SignedBy: auditName, Signature: mac.Sum(nil)}
+}
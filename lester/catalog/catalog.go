@@ -0,0 +1,155 @@
+// Package catalog loads Lester's heist scenarios and draws offers from them.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Rand is the source of randomness used to draw offers. *rand.Rand satisfies
+// it.
+type Rand interface {
+	Intn(n int) int
+	NormFloat64() float64
+}
+
+// Distribution is a normal distribution clamped to [Min, Max].
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// sample draws a value shifted by the given number of standard deviations.
+func (d Distribution) sample(rng Rand, shift float64) float64 {
+	v := d.Mean + d.StdDev*(rng.NormFloat64()+shift)
+	return math.Max(d.Min, math.Min(d.Max, v))
+}
+
+// zscore reports how many standard deviations v is away from the mean.
+func (d Distribution) zscore(v float64) float64 {
+	if d.StdDev == 0 {
+		return 0
+	}
+	return (v - d.Mean) / d.StdDev
+}
+
+// Scenario describes a kind of heist Lester can offer. LootRisk is how many
+// points of police risk each standard deviation of extra loot adds, and
+// RiskPenalty how many points of success each standard deviation of extra risk
+// takes away from every operator.
+type Scenario struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Targets     []string                `json:"targets"`
+	Weight      int                     `json:"weight"`
+	Loot        Distribution            `json:"loot"`
+	PoliceRisk  Distribution            `json:"police_risk"`
+	LootRisk    float64                 `json:"loot_risk"`
+	Success     map[string]Distribution `json:"success"`
+	RiskPenalty float64                 `json:"risk_penalty"`
+}
+
+// Offer is a heist drawn from a scenario. Success is keyed by operator.
+type Offer struct {
+	Scenario   string
+	Target     string
+	Loot       int32
+	PoliceRisk int32
+	Success    map[string]int32
+}
+
+// Load reads the scenario catalog from a JSON file.
+func Load(path string) ([]Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scenarios []Scenario
+	if err := json.Unmarshal(data, &scenarios); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("%s has no scenarios", path)
+	}
+	for _, sc := range scenarios {
+		if len(sc.Targets) == 0 {
+			return nil, fmt.Errorf("scenario %s has no targets", sc.ID)
+		}
+		if sc.Weight <= 0 {
+			return nil, fmt.Errorf("scenario %s needs a positive weight", sc.ID)
+		}
+		if len(sc.Success) == 0 {
+			return nil, fmt.Errorf("scenario %s rates no operator", sc.ID)
+		}
+	}
+	return scenarios, nil
+}
+
+// Unrated returns the IDs of the scenarios with no success distribution for
+// the operator, who cannot be staffed on their offers.
+func Unrated(scenarios []Scenario, operator string) []string {
+	var ids []string
+	for _, sc := range scenarios {
+		if _, ok := sc.Success[operator]; !ok {
+			ids = append(ids, sc.ID)
+		}
+	}
+	return ids
+}
+
+// Find returns the scenario with the given ID.
+func Find(scenarios []Scenario, id string) (Scenario, bool) {
+	for _, sc := range scenarios {
+		if sc.ID == id {
+			return sc, true
+		}
+	}
+	return Scenario{}, false
+}
+
+// Pick chooses a scenario at random, proportionally to its weight.
+func Pick(scenarios []Scenario, rng Rand) Scenario {
+	total := 0
+	for _, sc := range scenarios {
+		total += sc.Weight
+	}
+	n := rng.Intn(total)
+	for _, sc := range scenarios {
+		if n < sc.Weight {
+			return sc
+		}
+		n -= sc.Weight
+	}
+	return scenarios[len(scenarios)-1]
+}
+
+// Draw draws an offer from the scenario. Police risk goes up with the loot,
+// and the success of every operator the scenario rates goes down with the
+// risk. Operators are drawn in name order so a seeded rng is reproducible.
+func (sc Scenario) Draw(rng Rand) Offer {
+	loot := sc.Loot.sample(rng, 0)
+	risk := sc.PoliceRisk.sample(rng, sc.LootRisk*sc.Loot.zscore(loot)/math.Max(sc.PoliceRisk.StdDev, 1))
+	penalty := sc.RiskPenalty * sc.PoliceRisk.zscore(risk)
+	operators := make([]string, 0, len(sc.Success))
+	for op := range sc.Success {
+		operators = append(operators, op)
+	}
+	sort.Strings(operators)
+	success := make(map[string]int32, len(operators))
+	for _, op := range operators {
+		d := sc.Success[op]
+		success[op] = int32(d.sample(rng, -penalty/math.Max(d.StdDev, 1)))
+	}
+	return Offer{
+		Scenario:   sc.Name,
+		Target:     sc.Targets[rng.Intn(len(sc.Targets))],
+		Loot:       int32(loot),
+		PoliceRisk: int32(risk),
+		Success:    success,
+	}
+}
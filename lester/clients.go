@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// clientIDKey is the metadata key clients identify themselves with.
+	// Clients that do not send it are told apart by their address.
+	clientIDKey      = "client-id"
+	maxRejections    = 3
+	clientHistoryLen = 20
+	clientIdleTTL    = time.Hour
+)
+
+// offerEvent is an offer Lester showed a client and whether the client
+// already decided on it.
+type offerEvent struct {
+	offerID string
+	decided bool
+}
+
+// clientState is what Lester remembers about one client: how many offers in
+// a row it rejected and the offers it was shown, the only ones it may decide
+// on.
+type clientState struct {
+	mu         sync.Mutex
+	rejections int
+	lastSeen   time.Time
+	history    []offerEvent
+}
+
+// clientStore keeps the state of every client Lester has heard from.
+type clientStore struct {
+	mu      sync.Mutex
+	clients map[string]*clientState
+}
+
+var clients = &clientStore{clients: make(map[string]*clientState)}
+
+// clientID identifies the caller from the client-id metadata, or else from
+// the host it calls from.
+func clientID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(clientIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// get returns the client's state, creating it on first contact. Clients idle
+// for longer than clientIdleTTL are forgotten.
+func (s *clientStore) get(id string) *clientState {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for other, c := range s.clients {
+		c.mu.Lock()
+		idle := now.Sub(c.lastSeen) > clientIdleTTL
+		c.mu.Unlock()
+		if idle {
+			delete(s.clients, other)
+		}
+	}
+	c, ok := s.clients[id]
+	if !ok {
+		c = &clientState{}
+		s.clients[id] = c
+	}
+	c.mu.Lock()
+	c.lastSeen = now
+	c.mu.Unlock()
+	return c
+}
+
+// takeCooldown reports whether the client has rejected maxRejections offers
+// in a row and has to wait before the next one. The count starts over.
+func (c *clientState) takeCooldown() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rejections < maxRejections {
+		return false
+	}
+	c.rejections = 0
+	return true
+}
+
+// proposed records offers shown to the client, either proposed to it or on
+// the board it listed.
+func (c *clientState) proposed(offerIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range offerIDs {
+		if c.find(id) < 0 {
+			c.history = append(c.history, offerEvent{offerID: id})
+		}
+	}
+	if len(c.history) > clientHistoryLen {
+		c.history = c.history[len(c.history)-clientHistoryLen:]
+	}
+}
+
+// check reports whether the client may decide on the offer: only on offers
+// it was shown, and only once.
+func (c *clientState) check(offerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.find(offerID)
+	if i < 0 {
+		return status.Errorf(codes.PermissionDenied, "offer %q was not proposed to this client", offerID)
+	}
+	if c.history[i].decided {
+		return status.Errorf(codes.NotFound, "offer %q was already decided", offerID)
+	}
+	return nil
+}
+
+// decided records the client's decision and returns its rejections in a row.
+func (c *clientState) decided(offerID string, accepted bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := c.find(offerID); i >= 0 {
+		c.history[i].decided = true
+	}
+	if accepted {
+		c.rejections = 0
+	} else {
+		c.rejections++
+	}
+	return c.rejections
+}
+
+// find must be called with c.mu held.
+func (c *clientState) find(offerID string) int {
+	for i := len(c.history) - 1; i >= 0; i-- {
+		if c.history[i].offerID == offerID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"lester/catalog"
+	pb "lester/proto"
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	if scenarios, err = catalog.Load(defaultScenariosFile); err != nil {
+		log.Fatalf("Failed to load heist scenarios: %v", err)
+	}
+	log.SetOutput(testLogWriter{})
+	os.Exit(m.Run())
+}
+
+// testLogWriter drops Lester's logs, which are too chatty for hundreds of
+// concurrent clients.
+type testLogWriter struct{}
+
+func (testLogWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// asClient is a context carrying the client-id metadata of the given client.
+func asClient(ctx context.Context, id string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(clientIDKey, id))
+}
+
+// propose asks for offers until Lester hands one out, since he drops some
+// proposals on purpose.
+func propose(ctx context.Context, s *server, id string) (*pb.HeistOffer, error) {
+	for {
+		offer, err := s.ProposeHeistOffer(asClient(ctx, id), &pb.Empty{})
+		if err != nil || offer != nil {
+			return offer, err
+		}
+	}
+}
+
+func TestRejectionsArePerClient(t *testing.T) {
+	const rejecters, accepters = 20, 20
+	s := &server{}
+	var wg sync.WaitGroup
+	errs := make(chan error, rejecters+accepters)
+
+	for i := 0; i < rejecters; i++ {
+		id := fmt.Sprintf("rejecter-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; n <= maxRejections; n++ {
+				offer, err := propose(context.Background(), s, id)
+				if err != nil {
+					errs <- fmt.Errorf("%s: proposal %d: %v", id, n, err)
+					return
+				}
+				if _, err := s.DecideOnOffer(asClient(context.Background(), id), &pb.Decision{OfferId: offer.OfferId}); err != nil {
+					errs <- fmt.Errorf("%s: rejecting %s: %v", id, offer.OfferId, err)
+					return
+				}
+			}
+			// The next proposal has to wait out the cooldown.
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			if _, err := propose(ctx, s, id); status.Code(err) != codes.DeadlineExceeded {
+				errs <- fmt.Errorf("%s: want the cooldown after %d rejections, got %v", id, maxRejections, err)
+			}
+		}()
+	}
+	for i := 0; i < accepters; i++ {
+		id := fmt.Sprintf("accepter-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; n <= 2*maxRejections; n++ {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				offer, err := propose(ctx, s, id)
+				cancel()
+				if err != nil {
+					errs <- fmt.Errorf("%s: proposal %d should not wait for other clients' cooldowns: %v", id, n, err)
+					return
+				}
+				if _, err := s.DecideOnOffer(asClient(context.Background(), id), &pb.Decision{OfferId: offer.OfferId, Accepted: true}); err != nil {
+					errs <- fmt.Errorf("%s: accepting %s: %v", id, offer.OfferId, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestClientsCannotDecideOnOthersOffers(t *testing.T) {
+	s := &server{}
+	offer, err := propose(context.Background(), s, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const intruders = 50
+	var wg sync.WaitGroup
+	for i := 0; i < intruders; i++ {
+		id := fmt.Sprintf("intruder-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.DecideOnOffer(asClient(context.Background(), id), &pb.Decision{OfferId: offer.OfferId, Accepted: true})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("%s deciding on the owner's offer: got %v, want PermissionDenied", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	owner := asClient(context.Background(), "owner")
+	if _, err := s.DecideOnOffer(owner, &pb.Decision{OfferId: offer.OfferId}); err != nil {
+		t.Fatalf("owner rejecting its offer: %v", err)
+	}
+	if _, err := s.DecideOnOffer(owner, &pb.Decision{OfferId: offer.OfferId}); status.Code(err) != codes.NotFound {
+		t.Errorf("deciding twice: got %v, want NotFound", err)
+	}
+	for i := 0; i < intruders; i++ {
+		c := clients.get(fmt.Sprintf("intruder-%d", i))
+		c.mu.Lock()
+		rejections := c.rejections
+		c.mu.Unlock()
+		if rejections != 0 {
+			t.Errorf("intruder-%d has %d rejections, want 0", i, rejections)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"lester/catalog"
+	pb "lester/proto"
+)
+
+const (
+	// crewTTL is how long a registration lives without a heartbeat.
+	crewTTL           = 10 * time.Second
+	heartbeatInterval = 3 * time.Second
+)
+
+type crewMember struct {
+	registration *pb.OperatorRegistration
+	registered   time.Time
+	lastSeen     time.Time
+}
+
+// crewRegistry holds the operators that registered with Lester and keep
+// sending heartbeats. Members are keyed by name; registering again replaces
+// the previous registration.
+type crewRegistry struct {
+	mu      sync.Mutex
+	members map[string]*crewMember
+}
+
+var crew = &crewRegistry{members: make(map[string]*crewMember)}
+
+func (r *crewRegistry) register(reg *pb.OperatorRegistration) (*pb.RegistrationLease, error) {
+	if reg.Name == "" || reg.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "operators register with a name and an address")
+	}
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(now)
+	if old, ok := r.members[reg.Name]; ok && old.registration.Address != reg.Address {
+		log.Printf("%s moved from %s to %s", reg.Name, old.registration.Address, reg.Address)
+	} else if !ok {
+		log.Printf("%s joined the crew at %s with skills %v", reg.Name, reg.Address, reg.Skills)
+		if unrated := catalog.Unrated(scenarios, strings.ToLower(reg.Name)); len(unrated) > 0 {
+			log.Printf("No success rate for %s in scenarios %v, their offers cannot staff them", reg.Name, unrated)
+		}
+	}
+	r.members[reg.Name] = &crewMember{
+		registration: proto.Clone(reg).(*pb.OperatorRegistration),
+		registered:   now,
+		lastSeen:     now,
+	}
+	return lease(reg.Name, now), nil
+}
+
+// heartbeat renews the registration. Operators Lester does not know, such as
+// after Lester restarts or the registration expired, must register again.
+func (r *crewRegistry) heartbeat(hb *pb.Heartbeat) (*pb.RegistrationLease, error) {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(now)
+	member, ok := r.members[hb.Name]
+	if !ok || member.registration.Address != hb.Address {
+		return nil, status.Errorf(codes.NotFound, "%s at %s is not registered", hb.Name, hb.Address)
+	}
+	member.lastSeen = now
+	return lease(hb.Name, now), nil
+}
+
+// list returns the live members sorted by name.
+func (r *crewRegistry) list() []*pb.CrewMember {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(time.Now())
+	members := make([]*pb.CrewMember, 0, len(r.members))
+	for _, m := range r.members {
+		members = append(members, &pb.CrewMember{
+			Registration: proto.Clone(m.registration).(*pb.OperatorRegistration),
+			RegisteredMs: m.registered.UnixMilli(),
+			LastSeenMs:   m.lastSeen.UnixMilli(),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Registration.Name < members[j].Registration.Name })
+	return members
+}
+
+// expire must be called with r.mu held.
+func (r *crewRegistry) expire(now time.Time) {
+	for name, m := range r.members {
+		if now.Sub(m.lastSeen) > crewTTL {
+			log.Printf("%s missed its heartbeats, dropping it from the crew", name)
+			delete(r.members, name)
+		}
+	}
+}
+
+func lease(name string, now time.Time) *pb.RegistrationLease {
+	return &pb.RegistrationLease{
+		Name:        name,
+		ExpiresMs:   now.Add(crewTTL).UnixMilli(),
+		HeartbeatMs: heartbeatInterval.Milliseconds(),
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"lester/catalog"
+	pb "lester/proto"
+)
+
+const (
+	offerTTL  = 30 * time.Second
+	boardSize = 3
+)
+
+// offerBoard holds the offers Lester has handed out and not yet seen decided.
+// Offers proposed to a client belong to it, by client ID in owners; the rest
+// are on the board every client lists.
+type offerBoard struct {
+	mu     sync.Mutex
+	offers map[string]*pb.HeistOffer
+	owners map[string]string
+	next   int
+}
+
+var offers = &offerBoard{offers: make(map[string]*pb.HeistOffer), owners: make(map[string]string)}
+
+// globalRand draws from math/rand's global source, which is safe for
+// concurrent use by the gRPC handlers.
+type globalRand struct{}
+
+func (globalRand) Intn(n int) int       { return rand.Intn(n) }
+func (globalRand) NormFloat64() float64 { return rand.NormFloat64() }
+
+// drawOffer draws a new offer from a scenario of the catalog.
+func drawOffer() *pb.HeistOffer {
+	offer := catalog.Pick(scenarios, globalRand{}).Draw(globalRand{})
+	return &pb.HeistOffer{
+		Loot:       offer.Loot,
+		PoliceRisk: offer.PoliceRisk,
+		Success:    offer.Success,
+		Scenario:   offer.Scenario,
+		Target:     offer.Target,
+	}
+}
+
+// publish gives the offer an ID and an expiry time and keeps it for the
+// owner, or puts it on the board if there is none.
+func (b *offerBoard) publish(offer *pb.HeistOffer, owner string) *pb.HeistOffer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expire(time.Now())
+	b.next++
+	offer.OfferId = fmt.Sprintf("offer-%d", b.next)
+	offer.ExpiresMs = time.Now().Add(offerTTL).UnixMilli()
+	b.offers[offer.OfferId] = offer
+	if owner != "" {
+		b.owners[offer.OfferId] = owner
+	}
+	return proto.Clone(offer).(*pb.HeistOffer)
+}
+
+// list drops expired offers, tops the board up to boardSize fresh offers and
+// returns it with the offers proposed to the client, soonest to expire first.
+// Offers proposed to other clients are left out.
+func (b *offerBoard) list(client string, draw func() *pb.HeistOffer) []*pb.HeistOffer {
+	b.mu.Lock()
+	b.expire(time.Now())
+	missing := boardSize - (len(b.offers) - len(b.owners))
+	b.mu.Unlock()
+	for i := 0; i < missing; i++ {
+		b.publish(draw(), "")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	board := make([]*pb.HeistOffer, 0, len(b.offers))
+	for id, offer := range b.offers {
+		if owner, ok := b.owners[id]; ok && owner != client {
+			continue
+		}
+		board = append(board, proto.Clone(offer).(*pb.HeistOffer))
+	}
+	sort.Slice(board, func(i, j int) bool { return board[i].ExpiresMs < board[j].ExpiresMs })
+	return board
+}
+
+// take removes the offer from the board so the client can decide on it.
+// Unknown and expired offers, and offers proposed to other clients, cannot be
+// taken.
+func (b *offerBoard) take(offerID, client string) (*pb.HeistOffer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	offer, ok := b.offers[offerID]
+	if owner, owned := b.owners[offerID]; !ok || (owned && owner != client) {
+		return nil, status.Errorf(codes.NotFound, "unknown offer %q", offerID)
+	}
+	delete(b.offers, offerID)
+	delete(b.owners, offerID)
+	if time.Now().UnixMilli() > offer.ExpiresMs {
+		return nil, status.Errorf(codes.FailedPrecondition, "offer %s expired at %s", offerID, time.UnixMilli(offer.ExpiresMs).Format(time.TimeOnly))
+	}
+	return offer, nil
+}
+
+// expire must be called with b.mu held.
+func (b *offerBoard) expire(now time.Time) {
+	for id, offer := range b.offers {
+		if now.UnixMilli() > offer.ExpiresMs {
+			delete(b.offers, id)
+			delete(b.owners, id)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "lester/proto"
+)
+
+// TestBoardOffersAreTakenOnce has many clients list the board and race to
+// decide on every offer on it. Each offer goes to one client only.
+func TestBoardOffersAreTakenOnce(t *testing.T) {
+	const listers = 50
+	s := &server{}
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		taken = make(map[string]string)
+	)
+	for i := 0; i < listers; i++ {
+		id := fmt.Sprintf("lister-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			board, err := s.ListOffers(asClient(context.Background(), id), &pb.Empty{})
+			if err != nil {
+				t.Errorf("%s listing offers: %v", id, err)
+				return
+			}
+			if len(board.Offers) < boardSize {
+				t.Errorf("%s got %d offers, want at least %d", id, len(board.Offers), boardSize)
+			}
+			for _, offer := range board.Offers {
+				_, err := s.DecideOnOffer(asClient(context.Background(), id), &pb.Decision{OfferId: offer.OfferId, Accepted: true})
+				switch status.Code(err) {
+				case codes.OK:
+					mu.Lock()
+					if other, ok := taken[offer.OfferId]; ok {
+						t.Errorf("offer %s taken by both %s and %s", offer.OfferId, other, id)
+					}
+					taken[offer.OfferId] = id
+					mu.Unlock()
+				case codes.NotFound:
+					// Another client took it first.
+				default:
+					t.Errorf("%s deciding on listed offer %s: %v", id, offer.OfferId, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if len(taken) < boardSize {
+		t.Errorf("%d offers were taken, want at least %d", len(taken), boardSize)
+	}
+}
+
+func TestExpiredOffersCannotBeTaken(t *testing.T) {
+	s := &server{}
+	offer, err := propose(context.Background(), s, "late")
+	if err != nil {
+		t.Fatal(err)
+	}
+	offers.mu.Lock()
+	offers.offers[offer.OfferId].ExpiresMs = 0
+	offers.mu.Unlock()
+
+	_, err = s.DecideOnOffer(asClient(context.Background(), "late"), &pb.Decision{OfferId: offer.OfferId, Accepted: true})
+	if code := status.Code(err); code != codes.FailedPrecondition && code != codes.NotFound {
+		t.Errorf("deciding on an expired offer: got %v, want FailedPrecondition or NotFound", err)
+	}
+}
+
+// TestTwoClientsRaceForOneOffer has two clients decide on the same offer at
+// once. A board offer goes to one of them; an offer proposed to one client is
+// not listed to the other and only its owner can take it.
+func TestTwoClientsRaceForOneOffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		offer  func(t *testing.T, s *server, a, b string) *pb.HeistOffer
+		owners []string
+	}{
+		{
+			name: "board offer",
+			offer: func(t *testing.T, s *server, a, b string) *pb.HeistOffer {
+				boardA, err := s.ListOffers(asClient(context.Background(), a), &pb.Empty{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := s.ListOffers(asClient(context.Background(), b), &pb.Empty{}); err != nil {
+					t.Fatal(err)
+				}
+				return boardA.Offers[0]
+			},
+			owners: []string{"a", "b"},
+		},
+		{
+			name: "offer proposed to one client",
+			offer: func(t *testing.T, s *server, a, b string) *pb.HeistOffer {
+				offer, err := propose(context.Background(), s, a)
+				if err != nil {
+					t.Fatal(err)
+				}
+				board, err := s.ListOffers(asClient(context.Background(), b), &pb.Empty{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, listed := range board.Offers {
+					if listed.OfferId == offer.OfferId {
+						t.Errorf("offer %s proposed to %s was listed to %s", offer.OfferId, a, b)
+					}
+				}
+				return offer
+			},
+			owners: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{}
+			a, b := "race-a-"+tt.name, "race-b-"+tt.name
+			offer := tt.offer(t, s, a, b)
+
+			var wg sync.WaitGroup
+			errs := make(map[string]error)
+			var mu sync.Mutex
+			for _, id := range []string{a, b} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := s.DecideOnOffer(asClient(context.Background(), id), &pb.Decision{OfferId: offer.OfferId, Accepted: true})
+					mu.Lock()
+					errs[id] = err
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+
+			var winners []string
+			for name, id := range map[string]string{"a": a, "b": b} {
+				if errs[id] == nil {
+					winners = append(winners, name)
+				}
+			}
+			if len(winners) != 1 {
+				t.Fatalf("got %d clients taking offer %s, want 1: %v", len(winners), offer.OfferId, errs)
+			}
+			allowed := false
+			for _, owner := range tt.owners {
+				allowed = allowed || owner == winners[0]
+			}
+			if !allowed {
+				t.Errorf("client %s took offer %s, want one of %v", winners[0], offer.OfferId, tt.owners)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package police models how the police escalate during a hit, turn by turn.
+// Lester publishes the resulting stars; the simulator replays them offline.
+package police
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Model selects an escalation model. The values match
+// NotificationCommand.Model in heist.proto.
+type Model int32
+
+const (
+	Fixed Model = iota
+	Poisson
+	Heat
+	Events
+)
+
+const (
+	maxStars = 10
+	// poissonTurns is how many turns it takes, on average, to gain one star
+	// when the police risk is 100.
+	poissonTurns = 20
+	// heatPerStar is how much heat makes up one star, heatGain how much heat a
+	// noisy turn adds and heatDecay how much is kept on a quiet turn. The risk
+	// is the chance of a turn being noisy.
+	heatPerStar = 10.0
+	heatGain    = 1.5
+	heatDecay   = 0.97
+	// eventOdds is the chance per turn, out of 1000 at 100 risk, of an event
+	// that spikes the stars. Between events the stars cool down one star every
+	// eventCooldown turns.
+	eventOdds     = 25
+	eventCooldown = 50
+)
+
+// Escalation decides how the police respond to the hit, one turn at a time.
+// Turn returns the new star count and why it changed.
+type Escalation interface {
+	Turn(stars int) (int, string)
+}
+
+// New builds an escalation model. The fixed model adds a star every frequency
+// turns; the others scale with the police risk, from 0 to 100. All randomness
+// comes from the seed, so the same seed always yields the same star sequence.
+// A zero seed picks one from the clock.
+func New(model Model, frequency, policeRisk int, seed int64) Escalation {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	risk := float64(policeRisk) / 100
+
+	switch model {
+	case Poisson:
+		return &poissonModel{rng: rng, rate: risk / poissonTurns}
+	case Heat:
+		return &heatModel{rng: rng, risk: risk}
+	case Events:
+		return &eventModel{rng: rng, odds: int(risk * eventOdds)}
+	default:
+		if frequency <= 0 {
+			frequency = 1
+		}
+		return &fixedModel{frequency: frequency}
+	}
+}
+
+// fixedModel adds one star every frequency turns.
+type fixedModel struct {
+	frequency int
+	turn      int
+}
+
+func (m *fixedModel) Turn(stars int) (int, string) {
+	m.turn++
+	if m.turn%m.frequency == 0 {
+		return stars + 1, "police escalation"
+	}
+	return stars, ""
+}
+
+// poissonModel adds a Poisson distributed number of stars each turn, with a
+// rate proportional to the police risk.
+type poissonModel struct {
+	rng  *rand.Rand
+	rate float64
+}
+
+func (m *poissonModel) Turn(stars int) (int, string) {
+	// Knuth's algorithm, fine for the small rates used here.
+	limit := math.Exp(-m.rate)
+	arrivals := 0
+	for p := m.rng.Float64(); p > limit; p *= m.rng.Float64() {
+		arrivals++
+	}
+	if arrivals == 0 {
+		return stars, ""
+	}
+	return min(stars+arrivals, maxStars), "police arrivals"
+}
+
+// heatModel accumulates heat on noisy turns and lets it cool down on quiet
+// ones, so stars can go down as well as up.
+type heatModel struct {
+	rng  *rand.Rand
+	risk float64
+	heat float64
+}
+
+func (m *heatModel) Turn(stars int) (int, string) {
+	if m.rng.Float64() < m.risk {
+		m.heat += heatGain
+	} else {
+		m.heat *= heatDecay
+	}
+	next := min(int(m.heat/heatPerStar), maxStars)
+	switch {
+	case next > stars:
+		return next, "heat rising"
+	case next < stars:
+		return next, "heat cooling down"
+	}
+	return stars, ""
+}
+
+// eventModel keeps the stars steady until something goes wrong, then spikes
+// them by one to three stars. Without events the stars slowly wear off.
+type eventModel struct {
+	rng   *rand.Rand
+	odds  int
+	quiet int
+}
+
+func (m *eventModel) Turn(stars int) (int, string) {
+	if m.rng.Intn(1000) < m.odds {
+		m.quiet = 0
+		return min(stars+1+m.rng.Intn(3), maxStars), "witness called the police"
+	}
+	m.quiet++
+	if stars > 0 && m.quiet%eventCooldown == 0 {
+		return stars - 1, "police losing track"
+	}
+	return stars, ""
+}
@@ -0,0 +1,63 @@
+package police
+
+import (
+	"slices"
+	"testing"
+)
+
+const testTurns = 500
+
+// play runs the escalation for testTurns turns and returns the stars of each.
+func play(e Escalation) []int {
+	stars := make([]int, testTurns)
+	current := 0
+	for i := range stars {
+		current, _ = e.Turn(current)
+		stars[i] = current
+	}
+	return stars
+}
+
+func TestSeededModelsAreDeterministic(t *testing.T) {
+	tests := []struct {
+		name       string
+		model      Model
+		frequency  int
+		policeRisk int
+		random     bool // other seeds yield other stars
+	}{
+		{"fixed", Fixed, 25, 75, false},
+		{"poisson", Poisson, 0, 80, true},
+		{"heat", Heat, 0, 80, true},
+		{"events", Events, 0, 80, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := play(New(tt.model, tt.frequency, tt.policeRisk, 42))
+			again := play(New(tt.model, tt.frequency, tt.policeRisk, 42))
+			if !slices.Equal(first, again) {
+				t.Errorf("seed 42 yielded different stars:\n%v\n%v", first, again)
+			}
+			// The fixed model keeps adding stars, as Lester always did; the
+			// others stop at maxStars.
+			for turn, stars := range first {
+				if tt.random && (stars < 0 || stars > maxStars) {
+					t.Fatalf("turn %d has %d stars, want 0 to %d", turn, stars, maxStars)
+				}
+			}
+			other := play(New(tt.model, tt.frequency, tt.policeRisk, 7))
+			if differ := !slices.Equal(first, other); differ != tt.random {
+				t.Errorf("seeds 42 and 7 differ: %t, want %t", differ, tt.random)
+			}
+		})
+	}
+}
+
+func TestFixedModelAddsAStarEveryFrequencyTurns(t *testing.T) {
+	stars := play(New(Fixed, 25, 0, 1))
+	for turn, got := range stars {
+		if want := (turn + 1) / 25; got != want {
+			t.Fatalf("turn %d has %d stars, want %d", turn+1, got, want)
+		}
+	}
+}
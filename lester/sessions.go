@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "lester/proto"
+)
+
+const (
+	// sessionTTL is how long a stars session lives without being renewed.
+	sessionTTL           = 10 * time.Second
+	sessionRenewInterval = 3 * time.Second
+)
+
+var (
+	errSessionStopped = errors.New("stopped by Michael")
+	errLesterStopping = errors.New("Lester is shutting down")
+	errLeaseExpired   = errors.New("lease expired, Michael stopped renewing it")
+)
+
+// starSession is a running stars notification publisher for one heist.
+type starSession struct {
+	id      string
+	heistID string
+	model   pb.NotificationCommand_Model
+	started time.Time
+	cancel  context.CancelCauseFunc
+	done    chan struct{}
+
+	mu      sync.Mutex
+	stars   int
+	renewed time.Time
+}
+
+func (s *starSession) setStars(stars int) {
+	s.mu.Lock()
+	s.stars = stars
+	s.mu.Unlock()
+}
+
+// renew extends the session's lease from now.
+func (s *starSession) renew(now time.Time) {
+	s.mu.Lock()
+	s.renewed = now
+	s.mu.Unlock()
+}
+
+func (s *starSession) expired(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.renewed) > sessionTTL
+}
+
+func (s *starSession) toProto() *pb.NotificationSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &pb.NotificationSession{
+		SessionId:   s.id,
+		HeistId:     s.heistID,
+		Model:       s.model,
+		Stars:       int32(s.stars),
+		StartedMs:   s.started.UnixMilli(),
+		ExpiresMs:   s.renewed.Add(sessionTTL).UnixMilli(),
+		HeartbeatMs: sessionRenewInterval.Milliseconds(),
+	}
+}
+
+// sessionRegistry tracks the active stars sessions, at most one per heist.
+type sessionRegistry struct {
+	mu      sync.Mutex
+	byHeist map[string]*starSession
+	next    int
+}
+
+var sessions = &sessionRegistry{byHeist: make(map[string]*starSession)}
+
+// start registers a new session for the command's heist and runs its
+// publisher in the background until the session is stopped or its lease
+// expires.
+func (r *sessionRegistry) start(cmd *pb.NotificationCommand) (*starSession, error) {
+	if cmd.HeistId == "" {
+		return nil, status.Error(codes.InvalidArgument, "a heist ID is required to start stars notifications")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byHeist[cmd.HeistId]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "heist %s already has stars session %s", cmd.HeistId, existing.id)
+	}
+	r.next++
+	ctx, cancel := context.WithCancelCause(context.Background())
+	now := time.Now()
+	session := &starSession{
+		id:      fmt.Sprintf("%s-%d", cmd.HeistId, r.next),
+		heistID: cmd.HeistId,
+		model:   cmd.Model,
+		started: now,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		renewed: now,
+	}
+	r.byHeist[cmd.HeistId] = session
+
+	go func() {
+		defer close(session.done)
+		defer r.remove(session)
+		StartStarsNotification(ctx, cmd, session)
+	}()
+	return session, nil
+}
+
+// find returns the running session with the given ID.
+func (r *sessionRegistry) find(sessionID string) (*starSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.byHeist {
+		if s.id == sessionID {
+			return s, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no stars session %q is running", sessionID)
+}
+
+// stop ends the session with the given ID and waits for its publisher to
+// clean up. It fails if no such session is running.
+func (r *sessionRegistry) stop(sessionID string) (*starSession, error) {
+	session, err := r.find(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	session.cancel(errSessionStopped)
+	<-session.done
+	return session, nil
+}
+
+// renew extends the lease of the session with the given ID. Sessions whose
+// lease already expired cannot be renewed.
+func (r *sessionRegistry) renew(sessionID string) (*starSession, error) {
+	session, err := r.find(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if session.expired(now) {
+		return nil, status.Errorf(codes.NotFound, "stars session %q already expired", sessionID)
+	}
+	session.renew(now)
+	return session, nil
+}
+
+// stopAll ends every session and waits for their publishers to clean up.
+func (r *sessionRegistry) stopAll() int {
+	r.mu.Lock()
+	active := make([]*starSession, 0, len(r.byHeist))
+	for _, s := range r.byHeist {
+		active = append(active, s)
+	}
+	r.mu.Unlock()
+
+	for _, s := range active {
+		s.cancel(errLesterStopping)
+	}
+	for _, s := range active {
+		<-s.done
+	}
+	return len(active)
+}
+
+func (r *sessionRegistry) remove(session *starSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.byHeist[session.heistID] == session {
+		delete(r.byHeist, session.heistID)
+	}
+}
+
+// list returns the active sessions, oldest first.
+func (r *sessionRegistry) list() []*pb.NotificationSession {
+	r.mu.Lock()
+	active := make([]*starSession, 0, len(r.byHeist))
+	for _, s := range r.byHeist {
+		active = append(active, s)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(active, func(i, j int) bool { return active[i].started.Before(active[j].started) })
+	out := make([]*pb.NotificationSession, len(active))
+	for i, s := range active {
+		out[i] = s.toProto()
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout is how long the running calls get to finish once a shutdown
+// signal arrives.
+const shutdownTimeout = 15 * time.Second
+
+// serveUntilSignal serves until SIGINT or SIGTERM, then stops taking calls
+// and waits up to shutdownTimeout for the running ones to finish.
+func serveUntilSignal(server *grpc.Server, lis net.Listener) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		log.Printf("Shutting down, waiting up to %s for running calls", shutdownTimeout)
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			log.Printf("Calls still running after %s, closing them", shutdownTimeout)
+			server.Stop()
+		}
+	}()
+	return server.Serve(lis)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+
+	"lester/police"
+	"michael/assign"
+	pb "michael/proto"
+)
+
+// heistPhases are the phases an operator can be assigned to, in the order
+// they run.
+var heistPhases = assign.Phases
+
+// operatorProfiles holds the built-in profiles. connectCrew replaces them with
+// the crew registered with Lester.
+var operatorProfiles = map[string]assign.Profile{
+	"Franklin": {Skills: heistPhases, DistractionOdds: 0.10, FailStars: 5, AbilityStars: 3, ExtraPerTurn: 1000},
+	"Trevor":   {Skills: heistPhases, DistractionOdds: 0.10, FailStars: 7, AbilityStars: 5, ExtraPerTurn: 0},
+}
+
+// newPlanner plans with the crew's profiles and estimates the hit with the
+// stars model Michael asks Lester for. connectCrew builds one per run, so the
+// hits it plays are reused across offers and failovers.
+func newPlanner() *assign.Planner {
+	return assign.NewPlanner(operatorProfiles, police.Model(starsModel))
+}
+
+// planOffer is the offer as the planner sees it, with the success rate of
+// every operator in the crew.
+func planOffer(p *assign.Planner, offer *pb.HeistOffer) assign.Offer {
+	plan := assign.Offer{PoliceRisk: offer.PoliceRisk, Success: make(map[string]int32, len(p.Profiles))}
+	for name := range p.Profiles {
+		if rate, ok := successRate(offer, name); ok {
+			plan.Success[name] = rate
+		}
+	}
+	return plan
+}
+
+// successRate returns the operator's success rate for the offer. Lester rates
+// operators by lower-case name.
+func successRate(offer *pb.HeistOffer, ocName string) (int32, bool) {
+	rate, ok := offer.Success[strings.ToLower(ocName)]
+	return rate, ok
+}
+
+// bestSuccess returns the highest success rate in the offer.
+func bestSuccess(offer *pb.HeistOffer) int32 {
+	var best int32
+	for _, rate := range offer.Success {
+		best = max(best, rate)
+	}
+	return best
+}
+
+// phaseSuccess estimates the probability of the operator pulling off the
+// phase and the extra money they would earn doing it.
+func phaseSuccess(p *assign.Planner, phase, operator string, offer *pb.HeistOffer) (float64, float64) {
+	return p.PhaseSuccess(phase, operator, planOffer(p, offer))
+}
+
+// canRun reports whether the operator has the skill for the phase and a
+// success rate in the offer.
+func canRun(p *assign.Planner, operator, phase string, offer *pb.HeistOffer) bool {
+	return p.CanRun(operator, phase, planOffer(p, offer))
+}
+
+// optimizeAssignment assigns the planner's operators to the heist phases, at most one phase each, maximizing the probability of every phase
+// succeeding. Pinned phases keep their operator.
+func optimizeAssignment(p *assign.Planner, offer *pb.HeistOffer, pinned map[string]string) (assign.Assignment, error) {
+	return p.Optimize(planOffer(p, offer), pinned)
+}
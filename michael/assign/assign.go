@@ -0,0 +1,221 @@
+// Package assign plans which operator runs each phase of a heist. Michael
+// plans every heist with it and the simulator replays his plans offline.
+package assign
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"lester/police"
+)
+
+// DefaultTrials is how many hits EstimateHit plays when the planner sets none.
+const DefaultTrials = 200
+
+// Phases are the phases an operator can be assigned to, in the order they run.
+var Phases = []string{"distraction", "hit"}
+
+// Profile captures how an operator behaves: the phases they can run, the
+// chance of the midway roll in their distraction going wrong and, during the
+// hit, the number of stars that ends it, the number that activates their
+// ability and the extra money the ability earns each turn.
+type Profile struct {
+	Skills          []string
+	DistractionOdds float64
+	FailStars       int
+	AbilityStars    int
+	ExtraPerTurn    int
+}
+
+// Offer is what the planner needs from a heist offer: the police risk and
+// each operator's success rate, by operator name. Operators missing from
+// Success cannot take part.
+type Offer struct {
+	PoliceRisk int32
+	Success    map[string]int32
+}
+
+// Assignment maps each phase to the operator running it, with the estimated
+// probability of every phase succeeding and the extra money expected from the
+// hit when it succeeds.
+type Assignment struct {
+	Operators   map[string]string
+	Probability float64
+	Extra       float64
+}
+
+func (a Assignment) String() string {
+	parts := make([]string, 0, len(Phases))
+	for _, phase := range Phases {
+		parts = append(parts, fmt.Sprintf("%s by %s", phase, a.Operators[phase]))
+	}
+	return fmt.Sprintf("%s, p=%.2f", strings.Join(parts, ", "), a.Probability)
+}
+
+// Planner assigns the operators in Profiles to the phases, estimating the
+// hit with the escalation model Lester runs the stars with. It keeps the stars
+// of every hit it plays by police risk, so a planner reused across offers
+// plays each risk once.
+type Planner struct {
+	Profiles map[string]Profile
+	Model    police.Model
+	Trials   int
+
+	mu    sync.Mutex
+	stars map[int32][][]int
+}
+
+// NewPlanner returns a planner playing DefaultTrials hits per estimate.
+func NewPlanner(profiles map[string]Profile, model police.Model) *Planner {
+	return &Planner{Profiles: profiles, Model: model, Trials: DefaultTrials}
+}
+
+// CanRun reports whether the operator has the skill for the phase and a
+// success rate in the offer.
+func (p *Planner) CanRun(operator, phase string, offer Offer) bool {
+	if _, ok := offer.Success[operator]; !ok {
+		return false
+	}
+	for _, skill := range p.Profiles[operator].Skills {
+		if skill == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// PhaseSuccess estimates the probability of the operator pulling off the
+// phase and the extra money they would earn doing it.
+func (p *Planner) PhaseSuccess(phase, operator string, offer Offer) (float64, float64) {
+	if phase == "hit" {
+		return p.EstimateHit(operator, offer)
+	}
+	return 1 - p.Profiles[operator].DistractionOdds, 0
+}
+
+// EstimateHit plays the operator's hit against the planner's escalation
+// model, with the frequency and risk Michael starts the stars with. It returns
+// the fraction of hits finishing before the stars reach the operator's limit
+// and the average extra money of those. Every operator is played against the
+// same police.
+func (p *Planner) EstimateHit(operator string, offer Offer) (float64, float64) {
+	profile := p.Profiles[operator]
+	turns := 200 - int(offer.Success[operator])
+	trials := p.hits(offer.PoliceRisk, turns)
+
+	successes, extra := 0, 0
+	for _, stars := range trials {
+		earned, ability, failed := 0, false, false
+		for turn := 0; turn < turns && !failed; turn++ {
+			ability = ability || stars[turn] >= profile.AbilityStars
+			if ability {
+				earned += profile.ExtraPerTurn
+			}
+			failed = stars[turn] >= profile.FailStars
+		}
+		if !failed {
+			successes++
+			extra += earned
+		}
+	}
+	if successes == 0 {
+		return 0, 0
+	}
+	return float64(successes) / float64(len(trials)), float64(extra) / float64(successes)
+}
+
+// hits returns the stars of each turn of the planner's trials at the police
+// risk, for at least the given number of turns. The fixed model always yields
+// the same stars, so it is played once.
+func (p *Planner) hits(risk int32, turns int) [][]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if trials, ok := p.stars[risk]; ok && len(trials[0]) >= turns {
+		return trials
+	}
+	n := p.Trials
+	if n <= 0 {
+		n = DefaultTrials
+	}
+	if p.Model == police.Fixed {
+		n = 1
+	}
+	trials := make([][]int, n)
+	for i := range trials {
+		escalation := police.New(p.Model, int(100-risk), int(risk), int64(i+1))
+		trials[i] = make([]int, max(turns, 200))
+		stars := 0
+		for turn := range trials[i] {
+			stars, _ = escalation.Turn(stars)
+			trials[i][turn] = stars
+		}
+	}
+	if p.stars == nil {
+		p.stars = make(map[int32][][]int)
+	}
+	p.stars[risk] = trials
+	return trials
+}
+
+// Optimize assigns the operators to the phases, at most one phase each,
+// maximizing the probability of every phase succeeding. Pinned phases keep
+// their operator. Ties go to the assignment with more expected extra money,
+// then to the faster distraction, as the original rule of the best operator
+// running the distraction did.
+func (p *Planner) Optimize(offer Offer, pinned map[string]string) (Assignment, error) {
+	operators := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		operators = append(operators, name)
+	}
+	sort.Strings(operators)
+
+	const epsilon = 1e-9
+	best := Assignment{Probability: -1}
+	current := make(map[string]string, len(Phases))
+	used := make(map[string]bool, len(operators))
+	better := func(a Assignment) bool {
+		switch {
+		case math.Abs(a.Probability-best.Probability) > epsilon:
+			return a.Probability > best.Probability
+		case math.Abs(a.Extra-best.Extra) > epsilon:
+			return a.Extra > best.Extra
+		default:
+			return offer.Success[a.Operators["distraction"]] > offer.Success[best.Operators["distraction"]]
+		}
+	}
+
+	var search func(i int, probability, extra float64)
+	search = func(i int, probability, extra float64) {
+		if i == len(Phases) {
+			candidate := Assignment{Operators: make(map[string]string, len(current)), Probability: probability, Extra: extra}
+			for phase, op := range current {
+				candidate.Operators[phase] = op
+			}
+			if best.Operators == nil || better(candidate) {
+				best = candidate
+			}
+			return
+		}
+		phase := Phases[i]
+		for _, op := range operators {
+			if used[op] || (pinned[phase] != "" && pinned[phase] != op) || !p.CanRun(op, phase, offer) {
+				continue
+			}
+			success, e := p.PhaseSuccess(phase, op, offer)
+			used[op], current[phase] = true, op
+			search(i+1, probability*success, extra+e)
+			used[op] = false
+			delete(current, phase)
+		}
+	}
+	search(0, 1, 0)
+
+	if best.Operators == nil {
+		return Assignment{}, fmt.Errorf("no way to staff %s with %s, one phase each",
+			strings.Join(Phases, " and "), strings.Join(operators, ", "))
+	}
+	return best, nil
+}
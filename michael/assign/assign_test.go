@@ -0,0 +1,107 @@
+package assign
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"lester/police"
+)
+
+// testProfiles never fail the hit with 100 fail stars and always fail it with
+// none, so the estimates under the fixed model are exact.
+var testProfiles = map[string]Profile{
+	"Franklin": {Skills: Phases, DistractionOdds: 0.5, FailStars: 0},
+	"Trevor":   {Skills: Phases, DistractionOdds: 0.1, FailStars: 100},
+	"Lamar":    {Skills: Phases, DistractionOdds: 0.2, FailStars: 100},
+	"Lester":   {Skills: []string{"hit"}, FailStars: 100, ExtraPerTurn: 1000},
+}
+
+func TestOptimize(t *testing.T) {
+	everyone := map[string]int32{"Franklin": 60, "Trevor": 60, "Lamar": 60, "Lester": 60}
+	tests := []struct {
+		name     string
+		profiles []string
+		success  map[string]int32
+		pinned   map[string]string
+		want     map[string]string
+		p        float64
+		err      string
+	}{
+		{
+			name:    "four operators",
+			success: everyone,
+			want:    map[string]string{"distraction": "Trevor", "hit": "Lester"},
+			p:       0.9,
+		},
+		{
+			name:    "pinned distraction",
+			success: everyone,
+			pinned:  map[string]string{"distraction": "Lamar"},
+			want:    map[string]string{"distraction": "Lamar", "hit": "Lester"},
+			p:       0.8,
+		},
+		{
+			name:    "pinned hit",
+			success: everyone,
+			pinned:  map[string]string{"hit": "Trevor"},
+			want:    map[string]string{"distraction": "Lamar", "hit": "Trevor"},
+			p:       0.8,
+		},
+		{
+			name:    "operator missing from the offer",
+			success: map[string]int32{"Franklin": 60, "Lamar": 60, "Lester": 60},
+			want:    map[string]string{"distraction": "Lamar", "hit": "Lester"},
+			p:       0.8,
+		},
+		{
+			name:     "one operator cannot run both phases",
+			profiles: []string{"Trevor"},
+			success:  everyone,
+			err:      "no way to staff",
+		},
+		{
+			name:    "both phases pinned to one operator",
+			success: everyone,
+			pinned:  map[string]string{"distraction": "Trevor", "hit": "Trevor"},
+			err:     "no way to staff",
+		},
+		{
+			name:     "nobody else has the skill",
+			profiles: []string{"Lester", "Lamar"},
+			success:  everyone,
+			pinned:   map[string]string{"hit": "Lamar"},
+			err:      "no way to staff",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := testProfiles
+			if tt.profiles != nil {
+				profiles = make(map[string]Profile, len(tt.profiles))
+				for _, name := range tt.profiles {
+					profiles[name] = testProfiles[name]
+				}
+			}
+			planner := NewPlanner(profiles, police.Fixed)
+			got, err := planner.Optimize(Offer{PoliceRisk: 50, Success: tt.success}, tt.pinned)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("got %v, %v, want an error containing %q", got, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, phase := range Phases {
+				if got.Operators[phase] != tt.want[phase] {
+					t.Errorf("%s by %s, want %s", phase, got.Operators[phase], tt.want[phase])
+				}
+			}
+			if math.Abs(got.Probability-tt.p) > 1e-9 {
+				t.Errorf("p=%v, want %v", got.Probability, tt.p)
+			}
+		})
+	}
+}
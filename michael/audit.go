@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pb "michael/proto"
+)
+
+const (
+	defaultAuditFile = "cuts.audit.jsonl"
+	auditName        = "Michael"
+)
+
+// auditKeys are the crew members' HMAC keys by name, from AUDIT_KEYS (such as
+// "Michael=k1,Lester=k2,Franklin=k3,Trevor=k4"). Michael signs proposals and
+// seals entries with his own; the others verify their members' signatures.
+var auditKeys = map[string][]byte{}
+
+// auditMu serializes appends to the audit log, which are read-then-write.
+var auditMu sync.Mutex
+
+func loadAuditKeys() error {
+	v := os.Getenv("AUDIT_KEYS")
+	if v == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(v, ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || key == "" {
+			return fmt.Errorf("invalid AUDIT_KEYS entry %q, want Name=key", entry)
+		}
+		auditKeys[name] = []byte(key)
+	}
+	return nil
+}
+
+// auditEntry is a line of the audit log: a cut proposed to a crew member or
+// the member's answer. Each entry carries the hash of the one before it and
+// a seal over its own hash with Michael's key, so edited, removed or
+// reordered entries break the chain.
+type auditEntry struct {
+	Seq          int    `json:"seq"`
+	AtMs         int64  `json:"at_ms"`
+	HeistID      string `json:"heist_id"`
+	Kind         string `json:"kind"`
+	Member       string `json:"member"`
+	RequestID    string `json:"request_id"`
+	Loot         int32  `json:"loot"`
+	ExtraMoney   int32  `json:"extra_money"`
+	Cut          int32  `json:"cut"`
+	CrewSize     int32  `json:"crew_size,omitempty"`
+	Acknowledged bool   `json:"acknowledged,omitempty"`
+	Message      string `json:"message,omitempty"`
+	SignedBy     string `json:"signed_by,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+	Prev         string `json:"prev"`
+	Hash         string `json:"hash"`
+	Seal         string `json:"seal,omitempty"`
+}
+
+// auditHead is the head of the audit log Lester signed when Michael proposed
+// his cut: the number of entries and the hash of the last one. It is kept in
+// the heist's record, so the log can no longer lose entries from its end
+// without the record noticing.
+type auditHead struct {
+	Count     int    `json:"count"`
+	Hash      string `json:"hash"`
+	SignedBy  string `json:"signed_by,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+func newAuditHead(head *pb.AuditHead) *auditHead {
+	return &auditHead{
+		Count:     int(head.Count),
+		Hash:      head.Hash,
+		SignedBy:  head.SignedBy,
+		Signature: hex.EncodeToString(head.Signature),
+	}
+}
+
+// payload is what Lester signed, in the format Lester signs with.
+func (h auditHead) payload() string {
+	return fmt.Sprintf("head|%s|%d|%s", h.SignedBy, h.Count, h.Hash)
+}
+
+// digest hashes everything in the entry but its hash and seal.
+func (e auditEntry) digest() string {
+	e.Hash, e.Seal = "", ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// payload is what the signer of the entry signed. Answers use the format the
+// operators and Lester sign with.
+func (e auditEntry) payload() string {
+	if e.Kind == "proposal" {
+		return fmt.Sprintf("proposal|%s|%s|%d|%d|%d|%d", e.Member, e.RequestID, e.Loot, e.ExtraMoney, e.Cut, e.CrewSize)
+	}
+	return fmt.Sprintf("cut|%s|%s|%d|%d|%d|%d|%t|%s", e.SignedBy, e.RequestID, e.Loot, e.ExtraMoney, e.Cut, e.CrewSize, e.Acknowledged, e.Message)
+}
+
+func sign(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// auditCut appends the cut proposed to a crew member to the audit log, or the
+// member's answer when ack is set, and returns the entry.
+func auditCut(path, heistID, member string, details *pb.CutDetails, ack *pb.Ack) (auditEntry, error) {
+	entry := auditEntry{
+		AtMs:       time.Now().UnixMilli(),
+		HeistID:    heistID,
+		Kind:       "proposal",
+		Member:     member,
+		RequestID:  details.RequestId,
+		Loot:       details.Loot,
+		ExtraMoney: details.ExtraMoeny,
+		Cut:        details.ReceivedCut,
+		CrewSize:   details.CrewSize,
+	}
+	if ack == nil {
+		if key, ok := auditKeys[auditName]; ok {
+			entry.SignedBy = auditName
+			entry.Signature = sign(key, entry.payload())
+		}
+	} else {
+		entry.Kind = "response"
+		entry.Acknowledged, entry.Message = ack.Acknowledged, ack.Message
+		entry.SignedBy, entry.Signature = ack.SignedBy, hex.EncodeToString(ack.Signature)
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	entries, err := readAudit(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return auditEntry{}, err
+	}
+	entry.Seq = len(entries) + 1
+	if len(entries) > 0 {
+		entry.Prev = entries[len(entries)-1].Hash
+	}
+	entry.Hash = entry.digest()
+	if key, ok := auditKeys[auditName]; ok {
+		entry.Seal = sign(key, entry.Hash)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return auditEntry{}, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return auditEntry{}, err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return auditEntry{}, err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return auditEntry{}, err
+	}
+	return entry, file.Close()
+}
+
+func readAudit(path string) ([]auditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// verifyAudit checks the chain, seals and signatures of the audit log, that it
+// still reaches the heads Lester signed and that every paid heist of the
+// ledger has the answers of its crew. It returns
+// the problems found and how many signatures could not be checked for lack of
+// a signature or key. Seals are only checked with Michael's key.
+func verifyAudit(path, ledger string) (problems []string, unchecked int, err error) {
+	// A missing log is an empty one, which the heads in the ledger catch.
+	entries, err := readAudit(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, err
+	}
+	michaelKey, sealed := auditKeys[auditName]
+	prev, seq := "", 0
+	answered := make(map[string]bool)
+	hashes := make(map[int]string, len(entries))
+	for _, e := range entries {
+		hashes[e.Seq] = e.Hash
+		if e.Seq != seq+1 {
+			problems = append(problems, fmt.Sprintf("entry %d follows entry %d, entries are missing or out of order", e.Seq, seq))
+		}
+		seq = e.Seq
+		if e.Prev != prev {
+			problems = append(problems, fmt.Sprintf("entry %d does not follow the entry before it, which was removed or edited", e.Seq))
+		}
+		prev = e.Hash
+		if e.digest() != e.Hash {
+			problems = append(problems, fmt.Sprintf("entry %d was edited", e.Seq))
+		}
+		if sealed && !hmac.Equal([]byte(e.Seal), []byte(sign(michaelKey, e.Hash))) {
+			problems = append(problems, fmt.Sprintf("entry %d has a bad seal", e.Seq))
+		}
+
+		signer := auditName
+		if e.Kind == "response" {
+			signer = e.Member
+			answered[e.HeistID+"/"+e.Member] = true
+		}
+		key, ok := auditKeys[signer]
+		switch {
+		case e.Signature == "" || !ok:
+			unchecked++
+		case e.SignedBy != signer:
+			problems = append(problems, fmt.Sprintf("entry %d is signed by %q instead of %s", e.Seq, e.SignedBy, signer))
+		case !hmac.Equal([]byte(e.Signature), []byte(sign(key, e.payload()))):
+			problems = append(problems, fmt.Sprintf("entry %d does not match %s's signature", e.Seq, signer))
+		}
+	}
+
+	records, err := readLedger(ledger)
+	if errors.Is(err, fs.ErrNotExist) {
+		return problems, unchecked, nil
+	}
+	if err != nil {
+		return problems, unchecked, err
+	}
+	for _, record := range records {
+		head := record.AuditHead
+		if head == nil {
+			continue
+		}
+		key, ok := auditKeys["Lester"]
+		switch {
+		case head.Signature == "" || !ok:
+			unchecked++
+		case head.SignedBy != "Lester":
+			problems = append(problems, fmt.Sprintf("heist %s: the head of the log is signed by %q instead of Lester", record.HeistID, head.SignedBy))
+		case !hmac.Equal([]byte(head.Signature), []byte(sign(key, head.payload()))):
+			problems = append(problems, fmt.Sprintf("heist %s: the head of the log does not match Lester's signature", record.HeistID))
+		}
+		switch {
+		case head.Count > seq:
+			problems = append(problems, fmt.Sprintf("heist %s: Lester signed a log of %d entries but it ends at entry %d, entries were removed from its end", record.HeistID, head.Count, seq))
+		case hashes[head.Count] != head.Hash:
+			problems = append(problems, fmt.Sprintf("heist %s: entry %d is not the one Lester signed as the head of the log", record.HeistID, head.Count))
+		}
+	}
+	if len(entries) == 0 {
+		return problems, unchecked, nil
+	}
+	// Heists from before the audit log started are not in it.
+	started := false
+	for _, record := range records {
+		started = started || record.HeistID == entries[0].HeistID
+		if !started || !record.Success {
+			continue
+		}
+		for _, member := range append(record.participants(), "Lester") {
+			if !answered[record.HeistID+"/"+member] {
+				problems = append(problems, fmt.Sprintf("heist %s: %s's answer to their cut is missing", record.HeistID, member))
+			}
+		}
+	}
+	return problems, unchecked, nil
+}
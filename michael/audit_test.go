@@ -0,0 +1,141 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "michael/proto"
+)
+
+var testAuditKeys = map[string][]byte{
+	"Michael":  []byte("k1"),
+	"Lester":   []byte("k2"),
+	"Franklin": []byte("k3"),
+	"Trevor":   []byte("k4"),
+}
+
+// signedAck answers the cut as the member would, signing it the way the
+// operators and Lester do.
+func signedAck(member string, details *pb.CutDetails) *pb.Ack {
+	ack := &pb.Ack{Acknowledged: true, Message: "ok", SignedBy: member}
+	mac := hmac.New(sha256.New, testAuditKeys[member])
+	fmt.Fprintf(mac, "cut|%s|%s|%d|%d|%d|%d|%t|%s", ack.SignedBy, details.RequestId,
+		details.Loot, details.ExtraMoeny, details.ReceivedCut, details.CrewSize, ack.Acknowledged, ack.Message)
+	ack.Signature = mac.Sum(nil)
+	return ack
+}
+
+// writeAuditedHeist records a paid heist the way manageLootSplit does: the
+// cuts and answers of Franklin, Trevor and Lester in the audit log, and the
+// head Lester signed in the ledger.
+func writeAuditedHeist(t *testing.T, auditFile, ledger string) {
+	t.Helper()
+	record := heistRecord{HeistID: "42", DistractionBy: "Franklin", HitBy: "Trevor", Success: true}
+	for _, member := range []string{"Franklin", "Trevor", "Lester"} {
+		details := &pb.CutDetails{Loot: 900000, ExtraMoeny: 100000, ReceivedCut: 250000, RequestId: "42-cut-" + member, CrewSize: 4}
+		proposal, err := auditCut(auditFile, record.HeistID, member, details, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := auditCut(auditFile, record.HeistID, member, details, signedAck(member, details)); err != nil {
+			t.Fatal(err)
+		}
+		if member == "Lester" {
+			head := auditHead{Count: proposal.Seq, Hash: proposal.Hash, SignedBy: "Lester"}
+			head.Signature = sign(testAuditKeys["Lester"], head.payload())
+			record.AuditHead = &head
+		}
+	}
+	if err := appendLedger(ledger, record); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyAudit(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		want   []string // a problem containing each, none when empty
+	}{
+		{
+			name:   "intact",
+			tamper: func(lines []string) []string { return lines },
+		},
+		{
+			name: "edited entry",
+			tamper: func(lines []string) []string {
+				var e auditEntry
+				json.Unmarshal([]byte(lines[1]), &e)
+				e.Cut += 100000
+				data, _ := json.Marshal(e)
+				lines[1] = string(data)
+				return lines
+			},
+			want: []string{"entry 2 was edited", "entry 2 does not match Franklin's signature"},
+		},
+		{
+			name:   "removed entry",
+			tamper: func(lines []string) []string { return append(lines[:3], lines[4:]...) },
+			want:   []string{"entry 5 follows entry 3", "Trevor's answer to their cut is missing"},
+		},
+		{
+			name:   "truncated tail",
+			tamper: func(lines []string) []string { return lines[:len(lines)-2] },
+			want:   []string{"Lester signed a log of 5 entries but it ends at entry 4"},
+		},
+		{
+			name:   "empty log",
+			tamper: func(lines []string) []string { return nil },
+			want:   []string{"Lester signed a log of 5 entries but it ends at entry 0"},
+		},
+	}
+	saved := auditKeys
+	t.Cleanup(func() { auditKeys = saved })
+	auditKeys = testAuditKeys
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			auditFile, ledger := filepath.Join(dir, "cuts.audit.jsonl"), filepath.Join(dir, "heists.jsonl")
+			writeAuditedHeist(t, auditFile, ledger)
+			data, err := os.ReadFile(auditFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := tt.tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			tampered := strings.Join(lines, "\n")
+			if len(lines) > 0 {
+				tampered += "\n"
+			}
+			if err := os.WriteFile(auditFile, []byte(tampered), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			problems, unchecked, err := verifyAudit(auditFile, ledger)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if unchecked != 0 {
+				t.Errorf("%d signatures unchecked, want all checked", unchecked)
+			}
+			if len(tt.want) == 0 && len(problems) > 0 {
+				t.Errorf("got problems in an intact log: %v", problems)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, problem := range problems {
+					found = found || strings.Contains(problem, want)
+				}
+				if !found {
+					t.Errorf("no problem mentions %q in %v", want, problems)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"slices"
+	"time"
+
+	pb "michael/proto"
+)
+
+// cooldownThreshold is how long an offer has to take to count as one of
+// Lester's cooldowns after too many rejections.
+const cooldownThreshold = 5 * time.Second
+
+// heistRecord is the outcome of one heist, as recorded by a campaign and the
+// ledger.
+type heistRecord struct {
+	HeistID            string            `json:"heist_id"`
+	OffersSeen         int               `json:"offers_seen"`
+	OffersRejected     int               `json:"offers_rejected"`
+	CooldownHits       int               `json:"cooldown_hits"`
+	Scenario           string            `json:"scenario,omitempty"`
+	Target             string            `json:"target,omitempty"`
+	Loot               int32             `json:"loot"`
+	DistractionBy      string            `json:"distraction_by,omitempty"`
+	DistractionStatus  string            `json:"distraction_status,omitempty"`
+	HitBy              string            `json:"hit_by,omitempty"`
+	HitStatus          string            `json:"hit_status,omitempty"`
+	ExtraMoney         int32             `json:"extra_money"`
+	Cut                int32             `json:"cut"`
+	LesterCut          int32             `json:"lester_cut,omitempty"`
+	Remainder          int32             `json:"remainder,omitempty"`
+	Responses          map[string]string `json:"responses,omitempty"`
+	LesterResponse     string            `json:"lester_response,omitempty"`
+	SuccessProbability float64           `json:"success_probability,omitempty"`
+	Success            bool              `json:"success"`
+	TimedOut           bool              `json:"timed_out,omitempty"`
+	Failovers          []failover        `json:"failovers,omitempty"`
+	AuditHead          *auditHead        `json:"audit_head,omitempty"`
+	Message            string            `json:"message,omitempty"`
+}
+
+// participants returns the operators who ran a phase, in the order they ran.
+func (r heistRecord) participants() []string {
+	var names []string
+	for _, name := range []string{r.DistractionBy, r.HitBy} {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// phaseStats counts how often an operator ran a phase and how often it failed.
+type phaseStats struct {
+	Runs        int     `json:"runs"`
+	Failures    int     `json:"failures"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// campaignSummary aggregates the records of a campaign.
+type campaignSummary struct {
+	Heists            int                               `json:"heists"`
+	Successes         int                               `json:"successes"`
+	OffersSeen        int                               `json:"offers_seen"`
+	OffersAccepted    int                               `json:"offers_accepted"`
+	AcceptanceRate    float64                           `json:"acceptance_rate"`
+	PhaseFailures     map[string]map[string]*phaseStats `json:"phase_failures"`
+	AverageExtraMoney float64                           `json:"average_extra_money"`
+	AverageCut        float64                           `json:"average_cut"`
+	CooldownHits      int                               `json:"cooldown_hits"`
+	Records           []heistRecord                     `json:"records"`
+}
+
+// summarize builds the campaign summary. Extra money and cuts are averaged
+// over the successful heists.
+func summarize(records []heistRecord) campaignSummary {
+	summary := campaignSummary{
+		Heists:        len(records),
+		PhaseFailures: make(map[string]map[string]*phaseStats),
+		Records:       records,
+	}
+	// Phases without a status never ran, such as the hit of a heist whose
+	// distraction failed.
+	count := func(operator, phase, status string) {
+		if operator == "" || status == "" {
+			return
+		}
+		if summary.PhaseFailures[operator] == nil {
+			summary.PhaseFailures[operator] = make(map[string]*phaseStats)
+		}
+		stats := summary.PhaseFailures[operator][phase]
+		if stats == nil {
+			stats = &phaseStats{}
+			summary.PhaseFailures[operator][phase] = stats
+		}
+		stats.Runs++
+		if status != pb.PhaseStatus_SUCCESS.String() {
+			stats.Failures++
+		}
+		stats.FailureRate = float64(stats.Failures) / float64(stats.Runs)
+	}
+
+	var extra, cut int64
+	for _, r := range records {
+		summary.OffersSeen += r.OffersSeen
+		summary.OffersAccepted += r.OffersSeen - r.OffersRejected
+		summary.CooldownHits += r.CooldownHits
+		count(r.DistractionBy, "distraction", r.DistractionStatus)
+		count(r.HitBy, "hit", r.HitStatus)
+		if r.Success {
+			summary.Successes++
+			extra += int64(r.ExtraMoney)
+			cut += int64(r.Cut)
+		}
+	}
+	if summary.OffersSeen > 0 {
+		summary.AcceptanceRate = float64(summary.OffersAccepted) / float64(summary.OffersSeen)
+	}
+	if summary.Successes > 0 {
+		summary.AverageExtraMoney = float64(extra) / float64(summary.Successes)
+		summary.AverageCut = float64(cut) / float64(summary.Successes)
+	}
+	return summary
+}
+
+// writeCampaign writes the campaign summary as JSON.
+func writeCampaign(path string, records []heistRecord) error {
+	data, err := json.MarshalIndent(summarize(records), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("Campaign summary written to %s", path)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestSummarizePhaseFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []heistRecord
+		want    map[string]map[string]phaseStats
+	}{
+		{
+			name: "distraction failed, hit never ran",
+			records: []heistRecord{
+				{DistractionBy: "Franklin", DistractionStatus: "FAILURE", HitBy: "Trevor"},
+			},
+			want: map[string]map[string]phaseStats{
+				"Franklin": {"distraction": {Runs: 1, Failures: 1, FailureRate: 1}},
+			},
+		},
+		{
+			name: "both phases ran",
+			records: []heistRecord{
+				{DistractionBy: "Franklin", DistractionStatus: "SUCCESS", HitBy: "Trevor", HitStatus: "FAILURE"},
+				{DistractionBy: "Franklin", DistractionStatus: "SUCCESS", HitBy: "Trevor", HitStatus: "SUCCESS", Success: true},
+			},
+			want: map[string]map[string]phaseStats{
+				"Franklin": {"distraction": {Runs: 2}},
+				"Trevor":   {"hit": {Runs: 2, Failures: 1, FailureRate: 0.5}},
+			},
+		},
+		{
+			name: "offer never accepted",
+			records: []heistRecord{
+				{OffersSeen: 3, OffersRejected: 3},
+			},
+			want: map[string]map[string]phaseStats{},
+		},
+		{
+			name: "timed out hit after a failover",
+			records: []heistRecord{
+				{DistractionBy: "Franklin", DistractionStatus: "SUCCESS", HitBy: "Lamar", HitStatus: "TIMED_OUT"},
+			},
+			want: map[string]map[string]phaseStats{
+				"Franklin": {"distraction": {Runs: 1}},
+				"Lamar":    {"hit": {Runs: 1, Failures: 1, FailureRate: 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarize(tt.records).PhaseFailures
+			if len(got) != len(tt.want) {
+				t.Fatalf("got stats for %d operators, want %d: %v", len(got), len(tt.want), got)
+			}
+			for operator, phases := range tt.want {
+				if len(got[operator]) != len(phases) {
+					t.Errorf("%s: got %d phases, want %d", operator, len(got[operator]), len(phases))
+				}
+				for phase, want := range phases {
+					stats := got[operator][phase]
+					if stats == nil {
+						t.Errorf("%s: no stats for the %s", operator, phase)
+						continue
+					}
+					if *stats != want {
+						t.Errorf("%s's %s: got %+v, want %+v", operator, phase, *stats, want)
+					}
+				}
+			}
+		})
+	}
+}
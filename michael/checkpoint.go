@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "michael/proto"
+)
+
+const defaultCheckpointFile = "heist.checkpoint.json"
+
+// heistSteps are the steps of a heist Michael checkpoints, in order: the
+// offer was accepted, the distraction and the hit succeeded, and the loot was
+// retrieved from the operator who ran the hit. The cuts confirmed so far are
+// in the record's responses.
+var heistSteps = []string{"accepted", "distraction", "hit", "loot"}
+
+// checkpoint is the progress of the heist under way, saved after every step
+// so `michael run -resume` can pick it up after a crash or an interrupt.
+// Resumes counts how many times it was resumed.
+type checkpoint struct {
+	Step    string          `json:"step"`
+	Record  heistRecord     `json:"record"`
+	Offer   json.RawMessage `json:"offer"`
+	Resumes int             `json:"resumes,omitempty"`
+}
+
+// done reports whether the step was completed.
+func (cp *checkpoint) done(step string) bool {
+	return slices.Index(heistSteps, step) <= slices.Index(heistSteps, cp.Step)
+}
+
+func (cp *checkpoint) offer() (*pb.HeistOffer, error) {
+	var offer pb.HeistOffer
+	if err := protojson.Unmarshal(cp.Offer, &offer); err != nil {
+		return nil, fmt.Errorf("decoding the offer: %w", err)
+	}
+	return &offer, nil
+}
+
+// saveCheckpoint writes the checkpoint, replacing the previous one atomically.
+func saveCheckpoint(path string, cp *checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadCheckpoint reads the checkpoint of an interrupted heist.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no interrupted heist to resume in %s", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if !slices.Contains(heistSteps, cp.Step) {
+		return nil, fmt.Errorf("unknown step %q in %s", cp.Step, path)
+	}
+	return &cp, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "michael/proto"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	offer := &pb.HeistOffer{OfferId: "offer-1", Loot: 900000, PoliceRisk: 40, Success: map[string]int32{"franklin": 70, "trevor": 60}}
+	data, err := protojson.Marshal(offer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		step string
+		done []string
+	}{
+		{"accepted", []string{"accepted"}},
+		{"distraction", []string{"accepted", "distraction"}},
+		{"hit", []string{"accepted", "distraction", "hit"}},
+		{"loot", heistSteps},
+	}
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "heist.checkpoint.json")
+			record := heistRecord{HeistID: "42", DistractionBy: "Franklin", HitBy: "Trevor", Responses: map[string]string{"Franklin": "ok"}}
+			if err := saveCheckpoint(path, &checkpoint{Step: tt.step, Record: record, Offer: data, Resumes: 2}); err != nil {
+				t.Fatal(err)
+			}
+			cp, err := loadCheckpoint(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cp.Step != tt.step || cp.Resumes != 2 {
+				t.Errorf("got step %q after %d resumes, want %q after 2", cp.Step, cp.Resumes, tt.step)
+			}
+			if cp.Record.HeistID != "42" || cp.Record.HitBy != "Trevor" || cp.Record.Responses["Franklin"] != "ok" {
+				t.Errorf("got record %+v", cp.Record)
+			}
+			got, err := cp.offer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, offer) {
+				t.Errorf("got offer %v, want %v", got, offer)
+			}
+			for _, step := range heistSteps {
+				want := false
+				for _, done := range tt.done {
+					want = want || done == step
+				}
+				if cp.done(step) != want {
+					t.Errorf("done(%q) = %t, want %t", step, !want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCheckpointErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		data string // no file when empty
+		err  string
+	}{
+		{"no checkpoint", "", "no interrupted heist"},
+		{"unknown step", `{"step": "getaway"}`, `unknown step "getaway"`},
+		{"not json", `{"step":`, "decoding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-")+".json")
+			if tt.data != "" {
+				if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := loadCheckpoint(path); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+// fakeOperator and fakeLester count the calls of the loot split. Lester
+// answers the first few calls, as many as failures, with Unavailable.
+type fakeOperator struct {
+	pb.OperatorServiceClient
+	retrieved, cuts int
+}
+
+func (o *fakeOperator) RetrieveLoot(ctx context.Context, req *pb.OperatorRequest, opts ...grpc.CallOption) (*pb.LootDetails, error) {
+	o.retrieved++
+	return &pb.LootDetails{Loot: 900000, ExtraMoney: 100000}, nil
+}
+
+func (o *fakeOperator) ConfirmCut(ctx context.Context, details *pb.CutDetails, opts ...grpc.CallOption) (*pb.Ack, error) {
+	o.cuts++
+	return &pb.Ack{Acknowledged: true, Message: "ok"}, nil
+}
+
+type fakeLester struct {
+	pb.LesterServiceClient
+	failures, cuts int
+}
+
+func (l *fakeLester) ConfirmCut(ctx context.Context, details *pb.CutDetails, opts ...grpc.CallOption) (*pb.Ack, error) {
+	l.cuts++
+	if l.cuts <= l.failures {
+		return nil, status.Error(codes.Unavailable, "lester is down")
+	}
+	return &pb.Ack{Acknowledged: true, Message: "Excelente! el pago es correcto!"}, nil
+}
+
+// TestResumeLootSplit resumes the loot split after each of its steps. Nobody
+// is paid twice, and the checkpoint keeps every answer, Lester's included.
+func TestResumeLootSplit(t *testing.T) {
+	tests := []struct {
+		name           string
+		retrieved      bool
+		responses      map[string]string
+		lesterFailures int
+		retrieves      int
+		cuts           map[string]int
+		lesterCuts     int
+	}{
+		{
+			name:       "from the hit",
+			retrieves:  1,
+			cuts:       map[string]int{"Franklin": 1, "Trevor": 1},
+			lesterCuts: 1,
+		},
+		{
+			name:       "after one operator's cut",
+			retrieved:  true,
+			responses:  map[string]string{"Franklin": "ok"},
+			cuts:       map[string]int{"Trevor": 1},
+			lesterCuts: 1,
+		},
+		{
+			name:      "after Lester's cut",
+			retrieved: true,
+			responses: map[string]string{"Franklin": "ok", "Trevor": "ok", "Lester": "Excelente! el pago es correcto!"},
+		},
+		{
+			name:           "Lester comes back",
+			retrieved:      true,
+			responses:      map[string]string{"Franklin": "ok", "Trevor": "ok"},
+			lesterFailures: 1,
+			lesterCuts:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdir(t, t.TempDir())
+			operators := map[string]*fakeOperator{"Franklin": {}, "Trevor": {}}
+			lester := &fakeLester{failures: tt.lesterFailures}
+			c := &crew{lester: lester, operators: make(map[string]pb.OperatorServiceClient)}
+			for name, oc := range operators {
+				c.operators[name] = oc
+			}
+			record := heistRecord{HeistID: "42", DistractionBy: "Franklin", HitBy: "Trevor", Loot: 900000, ExtraMoney: 100000}
+			for name, response := range tt.responses {
+				if record.Responses == nil {
+					record.Responses = make(map[string]string)
+				}
+				record.Responses[name] = response
+			}
+			path := filepath.Join(t.TempDir(), "heist.checkpoint.json")
+			save := func(step string) {
+				if err := saveCheckpoint(path, &checkpoint{Step: step, Record: record}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, _, err := manageLootSplit(c, &record, tt.retrieved, "", save); err != nil {
+				t.Fatal(err)
+			}
+			if got := operators["Trevor"].retrieved; got != tt.retrieves {
+				t.Errorf("loot retrieved %d times, want %d", got, tt.retrieves)
+			}
+			for name, oc := range operators {
+				if oc.cuts != tt.cuts[name] {
+					t.Errorf("%s's cut confirmed %d times, want %d", name, oc.cuts, tt.cuts[name])
+				}
+			}
+			if lester.cuts != tt.lesterCuts {
+				t.Errorf("Lester's cut confirmed %d times, want %d", lester.cuts, tt.lesterCuts)
+			}
+			if !record.Success || record.LesterResponse == "" {
+				t.Errorf("got success %t and Lester's response %q", record.Success, record.LesterResponse)
+			}
+			if tt.lesterCuts == 0 {
+				return
+			}
+			cp, err := loadCheckpoint(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := cp.Record.Responses["Lester"]; !ok {
+				t.Errorf("the checkpoint lost Lester's answer: %v", cp.Record.Responses)
+			}
+		})
+	}
+}
+
+// chdir changes into dir for the rest of the test, where createReport writes
+// its report.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
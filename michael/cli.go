@@ -0,0 +1,448 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"michael/assign"
+	pb "michael/proto"
+)
+
+// Exit codes of the michael command.
+const (
+	exitOK                = 0
+	exitError             = 1
+	exitDistractionFailed = 3
+	exitHitFailed         = 4
+	exitCampaignFailures  = 5
+	exitTimedOut          = 6
+	exitAuditFailed       = 7
+	exitInterrupted       = 130
+)
+
+const (
+	defaultHost    = "192.168.1.6"
+	lesterPort     = "50051"
+	statusDeadline = 3 * time.Second
+)
+
+const usage = `usage: michael <command> [flags]
+
+commands:
+  plan             fetch Lester's offers and score them without deciding
+  run              coordinate a heist (the default); -resume continues an
+                   interrupted one from its checkpoint
+  status           query Lester and every registered operator
+  report <id>      re-render a past heist from the ledger
+  audit            verify the audit log of the cuts against the ledger
+
+exit codes: 0 success, 1 error, 3 distraction failed, 4 hit failed,
+5 some heists of a batch failed, 6 a phase timed out, 7 the audit log
+was tampered with, 130 interrupted
+`
+
+// crew holds the clients of everyone Michael coordinates. The operators are
+// the ones registered with Lester's crew registry, keyed by name.
+type crew struct {
+	lester    pb.LesterServiceClient
+	operators map[string]pb.OperatorServiceClient
+	members   []*pb.CrewMember
+	planner   *assign.Planner
+	conns     []*grpc.ClientConn
+}
+
+// connectCrew dials Lester at LESTER_HOST and every operator registered with
+// him. The registered profiles replace the built-in operatorProfiles.
+func connectCrew() (*crew, error) {
+	lesterHost := os.Getenv("LESTER_HOST")
+	if lesterHost == "" {
+		lesterHost = defaultHost
+	}
+	log.Printf("Using lester at %s", lesterHost)
+	if err := loadTimeouts(); err != nil {
+		return nil, err
+	}
+
+	c := &crew{operators: make(map[string]pb.OperatorServiceClient)}
+	dial := func(name, addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		conn, err := grpc.Dial(addr, append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("could not connect to %s: %w", name, err)
+		}
+		c.conns = append(c.conns, conn)
+		return conn, nil
+	}
+	lesterConn, err := dial("lester", lesterHost+":"+lesterPort, grpc.WithChainUnaryInterceptor(identify(clientIdentity()), withDeadlines()))
+	if err != nil {
+		return nil, err
+	}
+	c.lester = pb.NewLesterServiceClient(lesterConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusDeadline)
+	defer cancel()
+	registered, err := c.lester.ListCrew(ctx, &pb.Empty{})
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("could not get the crew from lester: %w", err)
+	}
+	c.members = registered.Members
+	profiles := make(map[string]assign.Profile, len(c.members))
+	for _, m := range c.members {
+		reg := m.Registration
+		conn, err := dial(reg.Name, reg.Address, grpc.WithUnaryInterceptor(withDeadlines()))
+		if err != nil {
+			return nil, err
+		}
+		c.operators[reg.Name] = pb.NewOperatorServiceClient(conn)
+		profile := operatorProfiles[reg.Name]
+		if p := reg.Profile; p != nil {
+			profile = assign.Profile{
+				FailStars:       int(p.FailStars),
+				AbilityStars:    int(p.AbilityStars),
+				ExtraPerTurn:    int(p.ExtraPerTurn),
+				DistractionOdds: float64(p.DistractionFailureOdds) / 100,
+			}
+		}
+		profile.Skills = reg.Skills
+		profiles[reg.Name] = profile
+		log.Printf("Crew: %s at %s, skills %v", reg.Name, reg.Address, reg.Skills)
+	}
+	operatorProfiles = profiles
+	c.planner = newPlanner()
+	return c, nil
+}
+
+// clientIdentity is how Michael introduces himself to Lester, who keeps
+// rejections and cooldowns per client: CLIENT_ID, or else the hostname.
+func clientIdentity() string {
+	if id := os.Getenv("CLIENT_ID"); id != "" {
+		return id
+	}
+	if host, err := os.Hostname(); err == nil {
+		return "michael@" + host
+	}
+	return "michael"
+}
+
+// identify adds the client-id metadata to every call.
+func identify(id string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, "client-id", id), method, req, reply, cc, opts...)
+	}
+}
+
+// operator returns the client of the named operator, or nil if they are not
+// in the crew.
+func (c *crew) operator(name string) *pb.OperatorServiceClient {
+	oc, ok := c.operators[name]
+	if !ok {
+		return nil
+	}
+	return &oc
+}
+
+// resolve finds the crew member with the given name, ignoring case.
+func (c *crew) resolve(name string) (string, error) {
+	for member := range c.operators {
+		if strings.EqualFold(member, name) {
+			return member, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not registered with lester", name)
+}
+
+func (c *crew) Close() {
+	for _, conn := range c.conns {
+		conn.Close()
+	}
+}
+
+// runCommand dispatches the subcommand and returns the exit code.
+func runCommand(args []string) int {
+	command := "run"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	switch command {
+	case "plan":
+		return planCommand(args)
+	case "run":
+		return runHeistCommand(args)
+	case "status":
+		return statusCommand(args)
+	case "report":
+		return reportCommand(args)
+	case "audit":
+		return auditCommand(args)
+	case "help":
+		fmt.Print(usage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		return exitError
+	}
+}
+
+func planCommand(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	strategyName := fs.String("strategy", "", "offer strategy, rule or ev (defaults to OFFER_STRATEGY)")
+	fs.Parse(args)
+
+	c, err := connectCrew()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	defer c.Close()
+	strategy, err := newOfferStrategy(*strategyName, c.planner, nil)
+	if err != nil {
+		log.Printf("Could not set up the offer strategy: %v", err)
+		return exitError
+	}
+
+	board, err := c.lester.ListOffers(context.Background(), &pb.Empty{})
+	if err != nil {
+		log.Printf("Could not list Lester's offers: %v", err)
+		return exitError
+	}
+	for _, offer := range board.Offers {
+		accept, reason := strategy.Evaluate(offer)
+		verdict := "reject"
+		if accept {
+			verdict = "accept"
+		}
+		fmt.Printf("%s  %s - %s  loot $%d  police risk %d  success %v  expires %s\n",
+			offer.OfferId, offer.Scenario, offer.Target, offer.Loot, offer.PoliceRisk,
+			offer.Success, time.UnixMilli(offer.ExpiresMs).Format(time.TimeOnly))
+		if plan, err := optimizeAssignment(c.planner, offer, nil); err != nil {
+			fmt.Printf("    cannot staff it: %v\n", err)
+		} else {
+			fmt.Printf("    %s\n", plan)
+		}
+		fmt.Printf("    %s: %s (%s)\n", strategy.Name(), verdict, reason)
+	}
+	return exitOK
+}
+
+func runHeistCommand(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	strategyName := fs.String("strategy", "", "offer strategy, rule or ev (defaults to OFFER_STRATEGY)")
+	distraction := fs.String("distraction", "", "pin the operator running the distraction")
+	hit := fs.String("hit", "", "pin the operator running the hit")
+	batch := fs.Int("batch", 0, "run this many heists back to back and write a campaign summary")
+	campaignOut := fs.String("campaign-out", "campaign.json", "where to write the campaign summary")
+	ledger := fs.String("ledger", defaultLedgerFile, "where to record the heists, empty to disable")
+	auditFile := fs.String("audit", defaultAuditFile, "where to record the cuts, empty to disable")
+	dashboardAddr := fs.String("dashboard", "", "serve the live dashboard on this address, such as :50052")
+	linger := fs.Duration("dashboard-linger", 30*time.Second, "how long to keep the dashboard up once the heists are over")
+	checkpointFile := fs.String("checkpoint", defaultCheckpointFile, "where to save the progress of the heist under way, empty to disable")
+	resume := fs.Bool("resume", false, "resume the interrupted heist saved in the checkpoint file")
+	fs.Parse(args)
+	handleInterrupts()
+	if err := loadAuditKeys(); err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	opts := runOptions{pinned: make(map[string]string), ledger: *ledger, audit: *auditFile, checkpoint: *checkpointFile}
+	if *resume {
+		if *batch > 0 {
+			log.Print("-resume cannot be combined with -batch")
+			return exitError
+		}
+		cp, err := loadCheckpoint(*checkpointFile)
+		if err != nil {
+			log.Print(err)
+			return exitError
+		}
+		opts.resume = cp
+	}
+
+	c, err := connectCrew()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	defer c.Close()
+
+	if len(c.members) < len(heistPhases) {
+		log.Printf("The crew is too small: %d operators registered for %d phases", len(c.members), len(heistPhases))
+		return exitError
+	}
+	if opts.resume != nil {
+		for _, name := range opts.resume.Record.participants() {
+			if c.operator(name) == nil {
+				log.Printf("Cannot resume heist %s: %s is not registered with lester", opts.resume.Record.HeistID, name)
+				return exitError
+			}
+		}
+	}
+
+	for phase, name := range map[string]string{"distraction": *distraction, "hit": *hit} {
+		if name == "" {
+			continue
+		}
+		if opts.pinned[phase], err = c.resolve(name); err != nil {
+			log.Print(err)
+			return exitError
+		}
+	}
+	if opts.strategy, err = newOfferStrategy(*strategyName, c.planner, opts.pinned); err != nil {
+		log.Printf("Could not set up the offer strategy: %v", err)
+		return exitError
+	}
+	if *dashboardAddr != "" {
+		if dash, err = startDashboard(*dashboardAddr); err != nil {
+			log.Printf("Could not start the dashboard: %v", err)
+			return exitError
+		}
+		checkInterval = dashboardCheckInterval
+		defer func() {
+			log.Printf("Keeping the dashboard up for %s", *linger)
+			time.Sleep(*linger)
+		}()
+	}
+
+	if *batch <= 0 {
+		return heistExitCode(runHeist(c, opts))
+	}
+
+	records := make([]heistRecord, 0, *batch)
+	code := exitOK
+	for i := 1; i <= *batch; i++ {
+		log.Printf("Campaign: heist %d of %d", i, *batch)
+		record := runHeist(c, opts)
+		if !record.Success {
+			code = exitCampaignFailures
+		}
+		records = append(records, record)
+	}
+	if err := writeCampaign(*campaignOut, records); err != nil {
+		log.Printf("Could not write the campaign summary: %v", err)
+		return exitError
+	}
+	return code
+}
+
+// heistExitCode maps the outcome of a heist to the exit code of `michael run`.
+func heistExitCode(record heistRecord) int {
+	switch {
+	case record.Success:
+		return exitOK
+	case record.TimedOut:
+		return exitTimedOut
+	case record.DistractionStatus == "":
+		return exitError
+	case record.DistractionStatus != pb.PhaseStatus_SUCCESS.String():
+		return exitDistractionFailed
+	case record.HitStatus == pb.PhaseStatus_SUCCESS.String():
+		// The loot could not be split.
+		return exitError
+	default:
+		return exitHitFailed
+	}
+}
+
+func statusCommand(args []string) int {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	fs.Parse(args)
+
+	c, err := connectCrew()
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	defer c.Close()
+
+	code := exitOK
+	ctx, cancel := context.WithTimeout(context.Background(), statusDeadline)
+	defer cancel()
+
+	sessions, err := c.lester.ListNotificationSessions(ctx, &pb.Empty{})
+	if err != nil {
+		fmt.Printf("Lester    unreachable: %v\n", err)
+		code = exitError
+	} else {
+		fmt.Printf("Lester    up, %d stars sessions\n", len(sessions.Sessions))
+		for _, s := range sessions.Sessions {
+			fmt.Printf("    %s  heist %s  model %s  %d stars  since %s  lease until %s\n", s.SessionId, s.HeistId, s.Model,
+				s.Stars, time.UnixMilli(s.StartedMs).Format(time.TimeOnly), time.UnixMilli(s.ExpiresMs).Format(time.TimeOnly))
+		}
+	}
+	fmt.Printf("Crew      %d operators registered\n", len(c.members))
+	for _, m := range c.members {
+		reg := m.Registration
+		status, err := (*c.operator(reg.Name)).CheckDistractionStatus(ctx, &pb.OperatorRequest{})
+		if err != nil {
+			fmt.Printf("%-9s unreachable at %s: %v\n", reg.Name, reg.Address, err)
+			code = exitError
+			continue
+		}
+		fmt.Printf("%-9s up at %s, skills %s, last heartbeat %s, %s, phase %s %s\n", reg.Name, reg.Address,
+			strings.Join(reg.Skills, ","), time.UnixMilli(m.LastSeenMs).Format(time.TimeOnly), status.State, status.Status, status.Message)
+	}
+	return code
+}
+
+func reportCommand(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	ledger := fs.String("ledger", defaultLedgerFile, "ledger the heist was recorded in")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: michael report [-ledger file] <heist id>\n")
+		return exitError
+	}
+
+	record, err := findLedger(*ledger, fs.Arg(0))
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	if err := renderReport(os.Stdout, record); err != nil {
+		log.Print(err)
+		return exitError
+	}
+	return exitOK
+}
+
+func auditCommand(args []string) int {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	auditFile := fs.String("audit", defaultAuditFile, "audit log of the cuts")
+	ledger := fs.String("ledger", defaultLedgerFile, "ledger the heists were recorded in")
+	fs.Parse(args)
+	if err := loadAuditKeys(); err != nil {
+		log.Print(err)
+		return exitError
+	}
+
+	problems, unchecked, err := verifyAudit(*auditFile, *ledger)
+	if err != nil {
+		log.Print(err)
+		return exitError
+	}
+	if _, ok := auditKeys[auditName]; !ok {
+		fmt.Printf("Michael's key is not in AUDIT_KEYS, seals were not checked\n")
+	}
+	if unchecked > 0 {
+		fmt.Printf("%d signatures were not checked, they are missing or their key is not in AUDIT_KEYS\n", unchecked)
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%s has %d problems\n", *auditFile, len(problems))
+		return exitAuditFailed
+	}
+	fmt.Printf("%s is intact\n", *auditFile)
+	return exitOK
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "michael/proto"
+)
+
+// dashboardCheckInterval replaces checkIntervalDuration while the dashboard is
+// on, so turns and stars are followed closely enough to watch.
+const (
+	dashboardCheckInterval = 100 * time.Millisecond
+	dashboardEvents        = 50
+)
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardState is what the browser shows. It is pushed whole on every
+// change. StarsFrom is "lester" while the stars come from Lester's updates;
+// otherwise they are the ones the operator reported when last polled.
+type dashboardState struct {
+	HeistID       string       `json:"heist_id"`
+	Mission       string       `json:"mission,omitempty"`
+	Phase         string       `json:"phase"`
+	Operator      string       `json:"operator,omitempty"`
+	Status        string       `json:"status,omitempty"`
+	Turn          int32        `json:"turn"`
+	TurnsNeeded   int32        `json:"turns_needed"`
+	Stars         int32        `json:"stars"`
+	StarsFrom     string       `json:"stars_from,omitempty"`
+	AbilityActive bool         `json:"ability_active"`
+	Record        *heistRecord `json:"record,omitempty"`
+	Events        []string     `json:"events"`
+}
+
+// dashboard serves the embedded page and streams dashboardState over
+// Server-Sent Events. Its methods do nothing on a nil dashboard, so the heist
+// code can call them unconditionally.
+type dashboard struct {
+	mu      sync.Mutex
+	state   dashboardState
+	clients map[chan []byte]struct{}
+}
+
+// dash is the dashboard of the running heist, nil unless `run -dashboard`.
+var dash *dashboard
+
+// startDashboard serves the dashboard on addr in the background.
+func startDashboard(addr string) (*dashboard, error) {
+	d := &dashboard{clients: make(map[chan []byte]struct{}), state: dashboardState{Phase: "idle", Events: []string{}}}
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(assets))
+	mux.HandleFunc("GET /events", d.serveEvents)
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if err := http.Serve(lis, mux); err != nil {
+			log.Printf("Dashboard stopped: %v", err)
+		}
+	}()
+	log.Printf("Dashboard listening on %s", addr)
+	return d, nil
+}
+
+// serveEvents sends the current state and then every change until the client
+// goes away.
+func (d *dashboard) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	updates := make(chan []byte, 1)
+	d.mu.Lock()
+	d.clients[updates] = struct{}{}
+	current, _ := json.Marshal(d.state)
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.clients, updates)
+		d.mu.Unlock()
+	}()
+
+	for data := current; ; {
+		fmt.Fprintf(w, "event: state\ndata: %s\n\n", data)
+		flusher.Flush()
+		select {
+		case data = <-updates:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// update applies change to the state and pushes the result to every client.
+// A client that has not read the previous state gets only the latest one.
+func (d *dashboard) update(change func(*dashboardState)) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	change(&d.state)
+	data, err := json.Marshal(d.state)
+	if err != nil {
+		log.Printf("Could not encode the dashboard state: %v", err)
+		return
+	}
+	for client := range d.clients {
+		select {
+		case <-client:
+		default:
+		}
+		client <- data
+	}
+}
+
+// event adds a line to the timeline.
+func (d *dashboard) event(format string, args ...any) {
+	line := time.Now().Format(time.TimeOnly) + " " + fmt.Sprintf(format, args...)
+	d.update(func(s *dashboardState) {
+		s.Events = append(s.Events, line)
+		if len(s.Events) > dashboardEvents {
+			s.Events = s.Events[len(s.Events)-dashboardEvents:]
+		}
+	})
+}
+
+// phase moves the dashboard to a new phase run by operator.
+func (d *dashboard) phase(phase, operator string) {
+	d.update(func(s *dashboardState) {
+		s.Phase, s.Operator, s.Status = phase, operator, ""
+		s.Turn, s.TurnsNeeded, s.Stars, s.StarsFrom, s.AbilityActive = 0, 0, 0, "", false
+	})
+	if operator != "" {
+		d.event("%s: %s takes over", phase, operator)
+	} else {
+		d.event("%s", phase)
+	}
+}
+
+// progress records what the operator reported, noting when their ability
+// kicks in.
+func (d *dashboard) progress(status *pb.PhaseStatus) {
+	if d == nil {
+		return
+	}
+	var activated string
+	d.update(func(s *dashboardState) {
+		if status.AbilityActive && !s.AbilityActive {
+			activated = s.Operator
+		}
+		s.Status = status.Status.String()
+		s.Turn, s.TurnsNeeded = status.Turn, status.TurnsNeeded
+		s.AbilityActive = status.AbilityActive
+		if s.StarsFrom == "" {
+			s.Stars = status.Stars
+		}
+	})
+	if activated != "" {
+		d.event("%s activated their ability at turn %d with %d stars", activated, status.Turn, status.Stars)
+	}
+}
+
+// stars records a star update from Lester.
+func (d *dashboard) stars(update *pb.StarUpdate) {
+	if d == nil {
+		return
+	}
+	d.update(func(s *dashboardState) { s.Stars = update.Stars })
+	if update.Final {
+		d.event("Lester ended the stars session at %d stars: %s", update.Stars, update.Reason)
+	}
+}
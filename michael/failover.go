@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+
+	pb "michael/proto"
+)
+
+// errOperatorUnavailable is returned when the operator assigned to a phase
+// fails its health check or cannot start the phase.
+var errOperatorUnavailable = errors.New("operator unavailable")
+
+// failover is a phase handed to another operator because the one assigned to
+// it was unavailable.
+type failover struct {
+	Phase  string `json:"phase"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// startPhase checks that the operator is up and has them start the phase.
+func startPhase(oc *pb.OperatorServiceClient, ocName, phase string, start func() error) error {
+	if _, err := (*oc).CheckDistractionStatus(context.Background(), &pb.OperatorRequest{}); err != nil {
+		return fmt.Errorf("%w: %s failed the health check: %v", errOperatorUnavailable, ocName, err)
+	}
+	if err := retryCall("start the "+phase, start); err != nil {
+		return fmt.Errorf("%w: %s could not start the %s: %v", errOperatorUnavailable, ocName, phase, err)
+	}
+	return nil
+}
+
+// runWithFailover runs the phase with the operator assigned to it in the
+// record. While the operator is unavailable, the phase is reassigned to the
+// best other operator who can run it and the reassignment is recorded.
+func runWithFailover(c *crew, record *heistRecord, phase string, offer *pb.HeistOffer, run func(oc *pb.OperatorServiceClient, ocName string) (*pb.PhaseStatus, error)) (*pb.PhaseStatus, error) {
+	assigned := &record.DistractionBy
+	if phase == "hit" {
+		assigned = &record.HitBy
+	}
+	tried := make(map[string]bool)
+	for {
+		dash.phase(phase, *assigned)
+		var status *pb.PhaseStatus
+		var err error
+		if oc := c.operator(*assigned); oc == nil {
+			err = fmt.Errorf("%w: %s is not in the crew", errOperatorUnavailable, *assigned)
+		} else {
+			status, err = run(oc, *assigned)
+		}
+		if !errors.Is(err, errOperatorUnavailable) {
+			return status, err
+		}
+
+		tried[*assigned] = true
+		next, ok := failoverCandidate(c, record, phase, offer, tried)
+		if !ok {
+			return nil, fmt.Errorf("no operator left for the %s: %w", phase, err)
+		}
+		log.Printf("Failover: %v, %s takes over the %s", err, next, phase)
+		dash.event("%s takes over the %s from %s", next, phase, *assigned)
+		record.Failovers = append(record.Failovers, failover{Phase: phase, From: *assigned, To: next, Reason: err.Error()})
+		*assigned = next
+	}
+}
+
+// failoverCandidate picks who takes over the phase among the operators not
+// tried yet: those not running another phase of the heist first, then the one
+// most likely to pull it off.
+func failoverCandidate(c *crew, record *heistRecord, phase string, offer *pb.HeistOffer, tried map[string]bool) (string, bool) {
+	var candidates []string
+	for name := range c.operators {
+		if !tried[name] && canRun(c.planner, name, phase, offer) {
+			candidates = append(candidates, name)
+		}
+	}
+	busy := record.participants()
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if busyA, busyB := slices.Contains(busy, a), slices.Contains(busy, b); busyA != busyB {
+			return busyB
+		}
+		successA, _ := phaseSuccess(c.planner, phase, a, offer)
+		successB, _ := phaseSuccess(c.planner, phase, b, offer)
+		if successA != successB {
+			return successA > successB
+		}
+		return a < b
+	})
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[0], true
+}
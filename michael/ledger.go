@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const defaultLedgerFile = "heists.jsonl"
+
+// appendLedger appends the record to the ledger, one JSON object per line.
+func appendLedger(path string, record heistRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// findLedger returns the ledger record of the given heist.
+func findLedger(path, heistID string) (heistRecord, error) {
+	records, err := readLedger(path)
+	if err != nil {
+		return heistRecord{}, err
+	}
+	for _, record := range records {
+		if record.HeistID == heistID {
+			return record, nil
+		}
+	}
+	return heistRecord{}, fmt.Errorf("heist %s is not in %s", heistID, path)
+}
+
+// readLedger returns every record of the ledger, oldest first.
+func readLedger(path string) ([]heistRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []heistRecord
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var record heistRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
@@ -7,277 +7,624 @@ import (
 	// "math/rand"
 	// "net"
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"michael/assign"
 	pb "michael/proto"
 )
 
 const checkIntervalDuration = 1 * time.Second
 
+// checkInterval is how often Michael polls the operator running a phase.
+var checkInterval = checkIntervalDuration
+
+// starsModel and starsSeed choose how Lester escalates the police during the
+// hit. STARS_MODEL is one of FIXED, POISSON, HEAT or EVENTS; a non-zero
+// STARS_SEED makes the star sequence reproducible.
+var (
+	starsModel   = pb.NotificationCommand_Model(pb.NotificationCommand_Model_value[strings.ToUpper(os.Getenv("STARS_MODEL"))])
+	starsSeed, _ = strconv.ParseInt(os.Getenv("STARS_SEED"), 10, 64)
+)
+
 func isOfferAcceptable(offer *pb.HeistOffer) bool {
-	return (offer.TrevorSuccess > 50 || offer.FranklinSuccess > 50) && offer.PoliceRisk < 80
+	return bestSuccess(offer) > 50 && offer.PoliceRisk < 80
 }
-func negotiateOffer(lc *pb.LesterServiceClient) *pb.HeistOffer {
+
+// negotiateOffer asks Lester for offers until the strategy accepts one the
+// crew can staff, and returns it with its assignment.
+func negotiateOffer(lc *pb.LesterServiceClient, planner *assign.Planner, strategy offerStrategy, pinned map[string]string, record *heistRecord) (*pb.HeistOffer, assign.Assignment, error) {
 	for {
+		asked := time.Now()
 		offer, err := (*lc).ProposeHeistOffer(context.Background(), &pb.Empty{})
 		if err != nil {
-			log.Fatal("Coud not get offer from lester: &v", err)
+			return nil, assign.Assignment{}, fmt.Errorf("could not get an offer from lester: %w", err)
 		}
-		if offer == nil {
+		if time.Since(asked) >= cooldownThreshold {
+			record.CooldownHits++
+		}
+		if offer == nil || offer.OfferId == "" {
 			log.Println("Lester didn't propose an offer, retrying...")
 			continue
 		}
-		log.Printf("Received offer: &{Loot: %d, PoliceRisk: %d, TrevorSuccess: %d, FranklinSuccess: %d}", offer.Loot, offer.PoliceRisk, offer.TrevorSuccess, offer.FranklinSuccess)
-		if isOfferAcceptable(offer) {
+		log.Printf("Received offer %s: &{Scenario: %s, Target: %s, Loot: %d, PoliceRisk: %d, Success: %v}", offer.OfferId, offer.Scenario, offer.Target, offer.Loot, offer.PoliceRisk, offer.Success)
+		record.OffersSeen++
+		accept, reason := strategy.Evaluate(offer)
+		log.Printf("Strategy %s: %s", strategy.Name(), reason)
+		plan, err := optimizeAssignment(planner, offer, pinned)
+		if accept && err != nil {
+			log.Printf("Cannot staff the offer: %v", err)
+			accept = false
+		}
+		if accept {
 			log.Println("Offer is acceptable, accepting...")
-			(*lc).DecideOnOffer(context.Background(), &pb.Decision{Accepted: true})
-			return offer
+			if _, err := (*lc).DecideOnOffer(context.Background(), &pb.Decision{Accepted: true, OfferId: offer.OfferId}); err != nil {
+				log.Printf("Lester refused the decision on offer %s, asking again: %v", offer.OfferId, err)
+				record.OffersRejected++
+				continue
+			}
+			return offer, plan, nil
 		} else {
 			log.Println("Offer is not acceptable, rejecting...")
-			(*lc).DecideOnOffer(context.Background(), &pb.Decision{Accepted: false})
+			record.OffersRejected++
+			(*lc).DecideOnOffer(context.Background(), &pb.Decision{Accepted: false, OfferId: offer.OfferId})
 			continue
 		}
 	}
 }
-func runDistraction(trevorClient, franklinClient *pb.OperatorServiceClient, offer *pb.HeistOffer) *pb.PhaseStatus {
-	var oc *pb.OperatorServiceClient
-	oc = trevorClient
-	var ocName string = "Trevor"
-	var turns_needed int32 = offer.TrevorSuccess
-	if offer.FranklinSuccess > offer.TrevorSuccess {
-		ocName = "Franklin"
-		oc = franklinClient
-		turns_needed = offer.FranklinSuccess
+
+// phaseRequestID is the request ID starting the phase. Each resume of the
+// heist uses a new one: the phase an interrupted Michael called off has to be
+// started again, not replayed from the operator's request log.
+func phaseRequestID(heistID, phase string, resumes int) string {
+	if resumes == 0 {
+		return heistID + "-" + phase
 	}
+	return fmt.Sprintf("%s-%s-%d", heistID, phase, resumes)
+}
+
+// phaseUnderway returns the operator's status and whether they are in one of
+// the states, that is, whether a resumed heist's phase already started before
+// the crash.
+func phaseUnderway(oc *pb.OperatorServiceClient, states ...pb.StateTransition_State) (*pb.PhaseStatus, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusDeadline)
+	defer cancel()
+	status, err := (*oc).CheckDistractionStatus(ctx, &pb.OperatorRequest{})
+	return status, err == nil && slices.Contains(states, status.State)
+}
+
+// runDistraction has the operator run the distraction and follows it until it
+// ends, or until the watchdog aborts it with errPhaseTimedOut. It returns
+// errOperatorUnavailable if the operator cannot start it.
+func runDistraction(oc *pb.OperatorServiceClient, ocName string, offer *pb.HeistOffer, heistID string, resumes int) (*pb.PhaseStatus, error) {
 	log.Printf("Running distraction with %s", ocName)
-	_, err := (*oc).StartDistraction(context.Background(), &pb.DistractionDetails{TurnsNeeded: 200 - turns_needed})
-	if err != nil {
-		log.Fatal("Could not start distraction: &v", err)
+	success, _ := successRate(offer, ocName)
+	details := &pb.DistractionDetails{TurnsNeeded: 200 - success, RequestId: phaseRequestID(heistID, "distraction", resumes)}
+	started := false
+	if resumes > 0 {
+		_, started = phaseUnderway(oc, pb.StateTransition_DISTRACTING, pb.StateTransition_DISTRACTED)
 	}
+	if started {
+		log.Printf("%s already started the distraction, following it", ocName)
+	} else if err := startPhase(oc, ocName, "distraction", func() error {
+		_, err := (*oc).StartDistraction(context.Background(), details)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	underway.phaseStarted(oc, ocName, "distraction")
+	defer underway.phaseEnded()
+	limit := deadlines.phase(details.TurnsNeeded)
+	watchdog := time.Now().Add(limit)
 	for {
-		time.Sleep(checkIntervalDuration)
-		status, err := (*oc).CheckDistractionStatus(context.Background(), &pb.Empty{})
+		time.Sleep(checkInterval)
+		if time.Now().After(watchdog) {
+			return nil, abortPhase(oc, ocName, "distraction", limit)
+		}
+		status, err := (*oc).CheckDistractionStatus(context.Background(), &pb.OperatorRequest{})
 		if err != nil {
-			log.Fatal("Could not check distraction status: &v", err)
+			log.Printf("Could not check distraction status: %v", err)
+			continue
 		}
+		dash.progress(status)
 		if status.Status != pb.PhaseStatus_IN_PROGESS {
 			log.Printf("Distraction finished with status: %v", status.Status)
-			return status
+			return status, nil
 		}
 	}
 }
-func runHit(trevorClient, franklinClient *pb.OperatorServiceClient, offer *pb.HeistOffer) (*pb.PhaseStatus, string) {
-	var oc *pb.OperatorServiceClient
-	oc = trevorClient
-	var ocName string = "Trevor"
-	var turns_needed int32 = offer.TrevorSuccess
-	if offer.FranklinSuccess < offer.TrevorSuccess {
-		ocName = "Franklin"
-		oc = franklinClient
-		turns_needed = offer.FranklinSuccess
-	}
+
+// runHit has the operator run the hit while Lester sends the stars, and
+// follows it until it ends, or until the watchdog aborts it with
+// errPhaseTimedOut. It returns errOperatorUnavailable if the operator cannot
+// start it.
+func runHit(oc *pb.OperatorServiceClient, ocName string, lesterClient *pb.LesterServiceClient, offer *pb.HeistOffer, heistID string, resumes int) (*pb.PhaseStatus, error) {
 	log.Printf("Running the HIT with %s", ocName)
-	_, err := (*oc).StartHit(context.Background(), &pb.HitDetails{TurnsNeeded: 200 - turns_needed, Loot: offer.Loot})
+	success, _ := successRate(offer, ocName)
+	details := &pb.HitDetails{TurnsNeeded: 200 - success, Loot: offer.Loot, HeistId: heistID, RequestId: phaseRequestID(heistID, "hit", resumes)}
+	var current *pb.PhaseStatus
+	started := false
+	if resumes > 0 {
+		current, started = phaseUnderway(oc, pb.StateTransition_HITTING, pb.StateTransition_LOOT_READY)
+	}
+	var stars int32
+	if started {
+		log.Printf("%s already started the hit, following it", ocName)
+		stars = current.Stars
+	} else if err := startPhase(oc, ocName, "hit", func() error {
+		_, err := (*oc).StartHit(context.Background(), details)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	underway.phaseStarted(oc, ocName, "hit")
+	defer underway.phaseEnded()
+	stopFollowing := followStars(heistID, stars)
+	defer stopFollowing()
+	// The operator is bound to the heist's stars exchange once StartHit
+	// returns, so Lester can start publishing without updates being dropped.
+	log.Printf("Starting Lester stars notifications")
+	session, err := startStarsSession(*lesterClient, &pb.NotificationCommand{
+		Command:    pb.NotificationCommand_START,
+		Frequency:  100 - offer.PoliceRisk,
+		HeistId:    heistID,
+		Model:      starsModel,
+		PoliceRisk: offer.PoliceRisk,
+		Seed:       starsSeed,
+		Stars:      stars,
+	})
 	if err != nil {
-		log.Fatal("Could not start hit: &v", err)
+		log.Printf("Could not start stars notifications: %v", err)
+	} else {
+		log.Printf("Stars session %s running", session.SessionId)
+		underway.sessionStarted(*lesterClient, session)
+		stopRenewing := renewStarsSession(*lesterClient, session)
+		defer func() {
+			stopRenewing()
+			underway.sessionEnded()
+			log.Printf("Stopping stars session %s", session.SessionId)
+			_, err := (*lesterClient).ManageStarsNotifications(context.Background(), &pb.NotificationCommand{
+				Command:   pb.NotificationCommand_STOP,
+				HeistId:   heistID,
+				SessionId: session.SessionId,
+			})
+			if err != nil {
+				log.Printf("Could not stop stars notifications: %v", err)
+			}
+		}()
 	}
+	limit := deadlines.phase(details.TurnsNeeded)
+	watchdog := time.Now().Add(limit)
 	for {
-		time.Sleep(checkIntervalDuration)
-		status, err := (*oc).CheckDistractionStatus(context.Background(), &pb.Empty{})
+		time.Sleep(checkInterval)
+		if time.Now().After(watchdog) {
+			return nil, abortPhase(oc, ocName, "hit", limit)
+		}
+		status, err := (*oc).CheckDistractionStatus(context.Background(), &pb.OperatorRequest{})
 		if err != nil {
-			log.Fatal("Could not check hit status: &v", err)
+			log.Printf("Could not check hit status: %v", err)
+			continue
 		}
+		dash.progress(status)
 		if status.Status != pb.PhaseStatus_IN_PROGESS {
 			log.Printf("Hit finished with status: %v", status.Status)
-			return status, ocName
+			return status, nil
 		}
 	}
 }
-func createReport(loot, extraMoney, totalLoot, franklinCut, trevorCut, lesterCut, remainder int32,
-	franklinResp, trevorResp, lesterResp string) {
-	file, err := os.Create("Reporte.txt")
-	if err != nil {
-		log.Fatal("Could not create report file: ", err)
+
+// startStarsSession starts Lester's stars session for the heist. A resumed
+// hit may still have the session Michael started before he was interrupted;
+// it is adopted, with its lease renewed right away, instead.
+func startStarsSession(lester pb.LesterServiceClient, cmd *pb.NotificationCommand) (*pb.NotificationSession, error) {
+	session, err := lester.ManageStarsNotifications(context.Background(), cmd)
+	if status.Code(err) != codes.AlreadyExists {
+		return session, err
 	}
-	defer file.Close()
+	running, listErr := lester.ListNotificationSessions(context.Background(), &pb.Empty{})
+	if listErr != nil {
+		return nil, err
+	}
+	for _, s := range running.Sessions {
+		if s.HeistId != cmd.HeistId {
+			continue
+		}
+		renewed, renewErr := lester.RenewStarsSession(context.Background(), &pb.SessionHeartbeat{SessionId: s.SessionId})
+		if renewErr != nil {
+			break
+		}
+		log.Printf("Adopting stars session %s, already running for heist %s", renewed.SessionId, renewed.HeistId)
+		return renewed, nil
+	}
+	// The session ended in the meantime, so a new one can start.
+	return lester.ManageStarsNotifications(context.Background(), cmd)
+}
 
-	// Format numbers with commas for thousands
-	formatNumber := func(num int32) string {
-		return fmt.Sprintf("$%d,%03d", num/1000, num%1000)
+// renewStarsSession keeps renewing the lease of Lester's stars session, so
+// Lester stops the session on its own if Michael goes away. It returns a
+// function that stops renewing.
+func renewStarsSession(lester pb.LesterServiceClient, session *pb.NotificationSession) func() {
+	interval := time.Duration(session.HeartbeatMs) * time.Millisecond
+	if interval <= 0 {
+		return func() {}
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_, err := lester.RenewStarsSession(context.Background(), &pb.SessionHeartbeat{SessionId: session.SessionId})
+				if status.Code(err) == codes.NotFound {
+					log.Printf("Stars session %s is gone: %v", session.SessionId, err)
+					return
+				}
+				if err != nil {
+					log.Printf("Could not renew stars session %s: %v", session.SessionId, err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
 	}
+}
 
-	// Write the report
-	writer := bufio.NewWriter(file)
+// phaseNames are the phases as the report names them.
+var phaseNames = map[string]string{"distraction": "distraccion", "hit": "golpe"}
+
+// formatNumber formats an amount with a thousands separator, as in the report.
+func formatNumber(num int32) string {
+	return fmt.Sprintf("$%d,%03d", num/1000, num%1000)
+}
+
+// renderReport writes the final report of the heist. Failed heists only get
+// the mission and what went wrong.
+func renderReport(w io.Writer, record heistRecord) error {
+	writer := bufio.NewWriter(w)
+	totalLoot := record.Loot + record.ExtraMoney
 
 	writer.WriteString("= = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = =\n")
 	writer.WriteString("== REPORTE FINAL DE LA MISION ==\n")
 	writer.WriteString("= = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = =\n")
-	writer.WriteString("Mision : Asalto al Banco # 7128\n")
+	writer.WriteString(fmt.Sprintf("Mision : %s - %s\n", record.Scenario, record.Target))
+	for _, f := range record.Failovers {
+		writer.WriteString(fmt.Sprintf("Reasignacion : %s de %s a %s ( %s )\n", phaseNames[f.Phase], f.From, f.To, f.Reason))
+	}
+	if !record.Success {
+		if record.TimedOut {
+			writer.WriteString("Resultado Global : MISION FRACASADA ( TIEMPO AGOTADO )\n")
+		} else {
+			writer.WriteString("Resultado Global : MISION FRACASADA\n")
+		}
+		writer.WriteString(fmt.Sprintf("Motivo : %s\n", record.Message))
+		writer.WriteString("= = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = =\n")
+		return writer.Flush()
+	}
 	writer.WriteString("Resultado Global : MISION COMPLETADA CON EXITO !\n")
 	writer.WriteString("--- REPARTO DEL BOTIN ---\n")
-	writer.WriteString(fmt.Sprintf("Botin Base : %s\n", formatNumber(loot)))
-	writer.WriteString(fmt.Sprintf("Botin Extra ( Habilidad de Chop ): %s\n", formatNumber(extraMoney)))
+	writer.WriteString(fmt.Sprintf("Botin Base : %s\n", formatNumber(record.Loot)))
+	writer.WriteString(fmt.Sprintf("Botin Extra ( Habilidad de Chop ): %s\n", formatNumber(record.ExtraMoney)))
 	writer.WriteString(fmt.Sprintf("Botin Total : %s\n", formatNumber(totalLoot)))
 	writer.WriteString("- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -\n")
-	writer.WriteString(fmt.Sprintf("Pago a Franklin : %s\n", formatNumber(franklinCut)))
-	writer.WriteString(fmt.Sprintf("Respuesta de Franklin : \"%s\"\n", franklinResp))
-	writer.WriteString(fmt.Sprintf("Pago a Trevor : %s\n", formatNumber(trevorCut)))
-	writer.WriteString(fmt.Sprintf("Respuesta de Trevor : \"%s\"\n", trevorResp))
-	writer.WriteString(fmt.Sprintf("Pago a Lester : %s ( reparto ) + %s ( resto )\n", formatNumber(lesterCut-remainder), formatNumber(remainder)))
-	writer.WriteString(fmt.Sprintf("Respuesta de Lester : \"%s\"\n", lesterResp))
+	for _, name := range record.participants() {
+		writer.WriteString(fmt.Sprintf("Pago a %s : %s\n", name, formatNumber(record.Cut)))
+		writer.WriteString(fmt.Sprintf("Respuesta de %s : \"%s\"\n", name, record.Responses[name]))
+	}
+	writer.WriteString(fmt.Sprintf("Pago a Lester : %s ( reparto ) + %s ( resto )\n", formatNumber(record.LesterCut-record.Remainder), formatNumber(record.Remainder)))
+	writer.WriteString(fmt.Sprintf("Respuesta de Lester : \"%s\"\n", record.LesterResponse))
 	writer.WriteString("- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -\n")
 	writer.WriteString(fmt.Sprintf("Saldo Final de la Operacion : %s\n", formatNumber(totalLoot)))
 	writer.WriteString("= = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = = =\n")
 
-	writer.Flush()
+	return writer.Flush()
+}
+func createReport(record heistRecord) {
+	file, err := os.Create("Reporte.txt")
+	if err != nil {
+		log.Fatal("Could not create report file: ", err)
+	}
+	defer file.Close()
 
+	if err := renderReport(file, record); err != nil {
+		log.Fatal("Could not write report file: ", err)
+	}
 	log.Println("Reporte.txt creado exitosamente")
 }
-func manageLootSplit(trevorClient, franklinClient *pb.OperatorServiceClient, lesterClient *pb.LesterServiceClient, ocName string) (int32, int32) {
-	var oc *pb.OperatorServiceClient
-	oc = trevorClient
-	if ocName == "Franklin" {
-		oc = franklinClient
-	} else {
-		oc = trevorClient
+
+// manageLootSplit retrieves the loot from the operator who ran the hit and
+// splits it evenly between Michael, Lester and every operator who ran a phase.
+// Lester also keeps the remainder. A resumed heist that already retrieved the
+// loot only confirms the cuts that were not confirmed yet. save checkpoints
+// the progress. It returns an error if a member cannot be reached, leaving
+// the heist unpaid.
+func manageLootSplit(c *crew, record *heistRecord, retrieved bool, auditFile string, save func(step string)) (int32, int32, error) {
+	if !retrieved {
+		oc := c.operator(record.HitBy)
+		lootDetails, err := (*oc).RetrieveLoot(context.Background(), &pb.OperatorRequest{})
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not retrieve the loot from %s: %w", record.HitBy, err)
+		}
+		record.Loot, record.ExtraMoney = lootDetails.Loot, lootDetails.ExtraMoney
+		save("loot")
 	}
-	lootDetails, err := (*oc).RetrieveLoot(context.Background(), &pb.Empty{})
-	if err != nil {
-		log.Fatal("Could not retrieve loot: &v", err)
+	if record.Responses == nil {
+		record.Responses = make(map[string]string)
 	}
-	loot := lootDetails.Loot
-	extraMoney := lootDetails.ExtraMoney
+	loot := record.Loot
+	extraMoney := record.ExtraMoney
 	totalLoot := loot + extraMoney
 
-	split := totalLoot / 4
-	remainder := totalLoot % 4
+	// Michael, Lester and every operator who ran a phase; one operator may
+	// have run both after a failover.
+	crewSize := int32(len(record.participants()) + 2)
+	split := totalLoot / crewSize
+	remainder := totalLoot % crewSize
 
 	lesterCut := split + remainder
-	franklinCut := split
-	trevorCut := split
 
-	ackTrevor, err := (*trevorClient).ConfirmCut(context.Background(), &pb.CutDetails{
-		Loot:        loot,
-		ExtraMoeny:  extraMoney,
-		ReceivedCut: trevorCut,
-	})
-	if err != nil {
-		log.Fatal("Could not retrieve loot: &v", err)
+	for _, name := range record.participants() {
+		if _, ok := record.Responses[name]; ok {
+			log.Printf("%s already confirmed their cut", name)
+			continue
+		}
+		details := &pb.CutDetails{
+			Loot:        loot,
+			ExtraMoeny:  extraMoney,
+			ReceivedCut: split,
+			RequestId:   record.HeistID + "-cut-" + name,
+			CrewSize:    crewSize,
+		}
+		recordCut(auditFile, record.HeistID, name, details, nil)
+		var ack *pb.Ack
+		err := retryCall("confirm "+name+"'s cut", func() error {
+			var err error
+			ack, err = (*c.operator(name)).ConfirmCut(context.Background(), details)
+			return err
+		})
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not confirm %s's cut: %w", name, err)
+		}
+		log.Printf("%s's response: %s", name, ack.Message)
+		recordCut(auditFile, record.HeistID, name, details, ack)
+		record.Responses[name] = ack.Message
+		save("loot")
 	}
-	log.Printf("Trevor's response: %s", ackTrevor.Message)
 
-	ackFranklin, err := (*franklinClient).ConfirmCut(context.Background(), &pb.CutDetails{
-		Loot:        loot,
-		ExtraMoeny:  extraMoney,
-		ReceivedCut: franklinCut,
-	})
-	if err != nil {
-		log.Fatal("Could not retrieve loot: &v", err)
+	if _, ok := record.Responses["Lester"]; ok {
+		log.Print("Lester already confirmed his cut")
+	} else {
+		lesterDetails := &pb.CutDetails{
+			Loot:        loot,
+			ExtraMoeny:  extraMoney,
+			ReceivedCut: lesterCut,
+			RequestId:   record.HeistID + "-cut-Lester",
+			CrewSize:    crewSize,
+		}
+		lesterDetails.AuditHead = recordCut(auditFile, record.HeistID, "Lester", lesterDetails, nil)
+		var ackLester *pb.Ack
+		err := retryCall("confirm Lester's cut", func() error {
+			var err error
+			ackLester, err = c.lester.ConfirmCut(context.Background(), lesterDetails)
+			return err
+		})
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not confirm Lester's cut: %w", err)
+		}
+		log.Printf("Lester's response: %s", ackLester.Message)
+		recordCut(auditFile, record.HeistID, "Lester", lesterDetails, ackLester)
+		if ackLester.AuditHead != nil {
+			record.AuditHead = newAuditHead(ackLester.AuditHead)
+		}
+		record.Responses["Lester"] = ackLester.Message
+		save("loot")
 	}
-	log.Printf("Franklin's response: %s", ackFranklin.Message)
 
-	ackLester, err := (*lesterClient).ConfirmCut(context.Background(), &pb.CutDetails{
-		Loot:        loot,
-		ExtraMoeny:  extraMoney,
-		ReceivedCut: lesterCut,
-	})
-	if err != nil {
-		log.Fatal("Could not retrieve loot: &v", err)
-	}
-	log.Printf("Lester's response: %s", ackLester.Message)
-	createReport(loot, extraMoney, totalLoot, franklinCut, trevorCut, lesterCut, remainder, ackFranklin.Message, ackTrevor.Message, ackLester.Message)
+	record.Cut, record.LesterCut, record.Remainder = split, lesterCut, remainder
+	record.LesterResponse = record.Responses["Lester"]
+	record.Success = true
+	createReport(*record)
 
-	return lootDetails.Loot, lootDetails.ExtraMoney
+	return loot, extraMoney, nil
 }
 
-func main() {
-	localHost := "192.168.1.6"
-	lesterHost := os.Getenv("LESTER_HOST")
-	trevorHost := os.Getenv("TREVOR_HOST")
-	franklinHost := os.Getenv("FRANKLIN_HOST")
-	if lesterHost == "" {
-		lesterHost = localHost
-	}
-	if trevorHost == "" {
-		trevorHost = localHost
+// recordCut adds a cut proposal, or its answer when ack is set, to the audit
+// log and returns the head of the log for Lester to sign. The heist goes on
+// without a head if it cannot be recorded.
+func recordCut(auditFile, heistID, member string, details *pb.CutDetails, ack *pb.Ack) *pb.AuditHead {
+	if auditFile == "" {
+		return nil
 	}
-	if franklinHost == "" {
-		franklinHost = localHost
-	}
-	log.Printf("Using hosts - lester: %s, trevor: %s, franklin: %s", lesterHost, trevorHost, franklinHost)
-	lesterConn, err := grpc.Dial("192.168.1.6:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	entry, err := auditCut(auditFile, heistID, member, details, ack)
 	if err != nil {
-		log.Fatalf("Could not connect to lester: %v", err)
+		log.Printf("Could not audit %s's cut of heist %s: %v", member, heistID, err)
+		return nil
 	}
-	defer lesterConn.Close()
-	lesterClient := pb.NewLesterServiceClient(lesterConn)
+	return &pb.AuditHead{Count: int32(entry.Seq), Hash: entry.Hash}
+}
 
-	franklinConn, err := grpc.Dial("192.168.1.6:50054", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatalf("Could not connect to franklin: %v", err)
+// phaseError ends the heist on a phase that timed out or that no operator
+// could run, reports it and returns the status of the phase.
+func phaseError(record *heistRecord, err error) string {
+	record.Message = err.Error()
+	record.TimedOut = errors.Is(err, errPhaseTimedOut)
+	createReport(*record)
+	if record.TimedOut {
+		return "TIMED_OUT"
 	}
-	defer franklinConn.Close()
-	franklinClient := pb.NewOperatorServiceClient(franklinConn)
+	return "UNAVAILABLE"
+}
 
-	trevorConn, err := grpc.Dial("192.168.1.6:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatalf("Could not connect to Trevor: %v", err)
+// runOptions are the knobs of `michael run`. Pinned phases keep their
+// operator; the rest are left to optimizeAssignment. The heist's progress is
+// saved to the checkpoint file, and resume continues the interrupted heist it
+// holds.
+type runOptions struct {
+	strategy   offerStrategy
+	pinned     map[string]string
+	ledger     string
+	audit      string
+	checkpoint string
+	resume     *checkpoint
+}
+
+// runHeist coordinates one heist from the offer to the loot split and records
+// how it went, with the cuts in the audit log. It checkpoints every step and
+// skips the steps a resumed heist already completed.
+func runHeist(c *crew, opts runOptions) heistRecord {
+	var (
+		record heistRecord
+		offer  *pb.HeistOffer
+		plan   assign.Assignment
+		err    error
+	)
+	cp := &checkpoint{}
+	if opts.resume != nil {
+		cp = opts.resume
+		record = cp.Record
+		if offer, err = cp.offer(); err != nil {
+			log.Fatalf("Could not resume heist %s: %v", record.HeistID, err)
+		}
+		cp.Resumes++
+		log.Printf("Resuming heist %s after the %s step", record.HeistID, cp.Step)
+	} else {
+		record.HeistID = fmt.Sprintf("%d", time.Now().UnixNano())
+		log.Printf("Coordinating heist %s", record.HeistID)
+	}
+	heistID := record.HeistID
+	save := func(step string) {
+		if opts.checkpoint == "" {
+			return
+		}
+		cp.Step, cp.Record = step, record
+		if err := saveCheckpoint(opts.checkpoint, cp); err != nil {
+			log.Printf("Could not checkpoint heist %s: %v", heistID, err)
+		}
 	}
-	defer trevorConn.Close()
-	trevorClient := pb.NewOperatorServiceClient(trevorConn)
 
-	log.Println("Coordinating: Phase 1, getting the offer from lester")
-	offer := negotiateOffer(&lesterClient)
-	log.Println("Coodinationg: Phase 1, success")
-	log.Printf("Accepted offer: &{Loot: %d, PoliceRisk: %d, TrevorSuccess: %d, FranklinSuccess: %d}", offer.Loot, offer.PoliceRisk, offer.TrevorSuccess, offer.FranklinSuccess)
+	if cp.Resumes > 0 {
+		save(cp.Step)
+	}
 
-	log.Println("Coordinating: Phase 2, running the distraction with Franklin")
-	distractionStatus := runDistraction(&trevorClient, &franklinClient, offer)
-	if distractionStatus.Status != pb.PhaseStatus_SUCCESS {
-		log.Printf("Coordinating: Phase 2, distraction failed, %s", distractionStatus.Message)
-		return
+	dash.update(func(s *dashboardState) { *s = dashboardState{HeistID: heistID, Events: s.Events} })
+	dash.phase("offer", "Lester")
+	defer func() {
+		dash.update(func(s *dashboardState) {
+			s.Record, s.Phase = &record, "done"
+			if !record.Success {
+				s.Phase = "failed"
+			}
+		})
+		if record.Success {
+			dash.event("Heist %s done, $%d for each of the crew", heistID, record.Cut)
+		} else {
+			dash.event("Heist %s failed: %s", heistID, record.Message)
+		}
+		if opts.checkpoint != "" {
+			if err := os.Remove(opts.checkpoint); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Printf("Could not remove the checkpoint of heist %s: %v", heistID, err)
+			}
+		}
+		if opts.ledger == "" {
+			return
+		}
+		if err := appendLedger(opts.ledger, record); err != nil {
+			log.Printf("Could not record heist %s in %s: %v", heistID, opts.ledger, err)
+		}
+	}()
+
+	if !cp.done("accepted") {
+		log.Println("Coordinating: Phase 1, getting the offer from lester")
+		if offer, plan, err = negotiateOffer(&c.lester, c.planner, opts.strategy, opts.pinned, &record); err != nil {
+			log.Printf("Coordinating: Phase 1, %v", err)
+			record.Message = err.Error()
+			createReport(record)
+			return record
+		}
+		log.Println("Coodinationg: Phase 1, success")
+		log.Printf("Accepted offer: &{Scenario: %s, Target: %s, Loot: %d, PoliceRisk: %d, Success: %v}", offer.Scenario, offer.Target, offer.Loot, offer.PoliceRisk, offer.Success)
+
+		record.Scenario, record.Target, record.Loot = offer.Scenario, offer.Target, offer.Loot
+		record.DistractionBy, record.HitBy = plan.Operators["distraction"], plan.Operators["hit"]
+		record.SuccessProbability = plan.Probability
+		log.Printf("Assignment: %s", plan)
+		if cp.Offer, err = protojson.Marshal(offer); err != nil {
+			log.Fatalf("Could not encode the offer: %v", err)
+		}
+		save("accepted")
 	}
-	log.Println("Coordinating: Phase 2, success")
-	log.Println("Coordinating: Phase 3, the hit")
-	log.Printf("Starting Lester stars notifications")
-	lesterClient.ManageStarsNotifications(context.Background(), &pb.NotificationCommand{
-		Command:   pb.NotificationCommand_START,
-		Frequency: 100 - offer.PoliceRisk,
-	})
-	hitStatus, ocName := runHit(&trevorClient, &franklinClient, offer)
-	if hitStatus.Status != pb.PhaseStatus_SUCCESS {
-		log.Printf("Coordinating: Phase 3, hit failed, %s", hitStatus.Message)
-		lesterClient.ManageStarsNotifications(context.Background(), &pb.NotificationCommand{
-			Command: pb.NotificationCommand_STOP,
+	dash.update(func(s *dashboardState) { s.Mission = offer.Scenario + " - " + offer.Target })
+
+	if !cp.done("distraction") {
+		log.Printf("Coordinating: Phase 2, running the distraction with %s", record.DistractionBy)
+		distractionStatus, err := runWithFailover(c, &record, "distraction", offer, func(oc *pb.OperatorServiceClient, ocName string) (*pb.PhaseStatus, error) {
+			return runDistraction(oc, ocName, offer, heistID, cp.Resumes)
 		})
-		return
+		if err != nil {
+			log.Printf("Coordinating: Phase 2, %v", err)
+			record.DistractionStatus = phaseError(&record, err)
+			return record
+		}
+		record.DistractionStatus = distractionStatus.Status.String()
+		if distractionStatus.Status != pb.PhaseStatus_SUCCESS {
+			log.Printf("Coordinating: Phase 2, distraction failed, %s", distractionStatus.Message)
+			record.Message = distractionStatus.Message
+			return record
+		}
+		log.Println("Coordinating: Phase 2, success")
+		save("distraction")
 	}
-	lesterClient.ManageStarsNotifications(context.Background(), &pb.NotificationCommand{
-		Command: pb.NotificationCommand_STOP,
-	})
-	log.Printf("Hit completed, totalLoot: $%d, extraMoney: $%d", hitStatus.TotalLoot, hitStatus.ExtraMoney)
-	log.Println("Coordinating: Phase 3, the hit, success")
+
+	if !cp.done("hit") {
+		log.Printf("Coordinating: Phase 3, the hit with %s", record.HitBy)
+		hitStatus, err := runWithFailover(c, &record, "hit", offer, func(oc *pb.OperatorServiceClient, ocName string) (*pb.PhaseStatus, error) {
+			return runHit(oc, ocName, &c.lester, offer, heistID, cp.Resumes)
+		})
+		if err != nil {
+			log.Printf("Coordinating: Phase 3, %v", err)
+			record.HitStatus = phaseError(&record, err)
+			return record
+		}
+		record.HitStatus = hitStatus.Status.String()
+		if hitStatus.Status != pb.PhaseStatus_SUCCESS {
+			log.Printf("Coordinating: Phase 3, hit failed, %s", hitStatus.Message)
+			record.Message = hitStatus.Message
+			return record
+		}
+		log.Printf("Hit completed, totalLoot: $%d, extraMoney: $%d", hitStatus.TotalLoot, hitStatus.ExtraMoney)
+		log.Println("Coordinating: Phase 3, the hit, success")
+		save("hit")
+	}
+
 	log.Println("Coordinating: Phase 4, managing the loot split")
-	loot, extraMoney := manageLootSplit(&trevorClient, &franklinClient, &lesterClient, ocName)
+	dash.phase("split", "Michael")
+	loot, extraMoney, err := manageLootSplit(c, &record, cp.done("loot"), opts.audit, save)
+	if err != nil {
+		log.Printf("Coordinating: Phase 4, %v", err)
+		record.Message = err.Error()
+		createReport(record)
+		return record
+	}
 	log.Printf("Loot retrieved: $%d, extraMoney: $%d", loot, extraMoney)
-	return
-
-	// var distractionStatus *pb.PhaseStatus
-	// if offer.FranklinSuccess > offer.TrevorSuccess {
-	// 	log.Println("Coordinating: Phase 2, running the distraction with Franklin")
-	// 	distractionStatus = runDistraction(&franklinClient, offer)
-	// } else {
-	// 	log.Println("Coordinating: Phase 2, running the distraction with Trevor")
-	// 	distractionStatus = runDistraction(&trevorClient, offer)
-	// }
-	//
-	// if distractionStatus.Status != pb.PhaseStatus_SUCCESS {
-	// 	log.Printf("Coordinating: Phase 2, distraction failed, %s", distractionStatus.Message)
-	// 	return
-	// }
+	return record
+}
 
+func main() {
+	os.Exit(runCommand(os.Args[1:]))
 }
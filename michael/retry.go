@@ -0,0 +1,32 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxCallAttempts = 3
+	retryBackoff    = 500 * time.Millisecond
+)
+
+// retryCall retries a call that failed because the operator could not be
+// reached or did not answer in time. Only calls carrying a request ID may be
+// retried, since the operator then runs them once however often they arrive.
+func retryCall(what string, call func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxCallAttempts; attempt++ {
+		err = call()
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			log.Printf("Could not %s (attempt %d): %v", what, attempt, err)
+			time.Sleep(retryBackoff)
+		default:
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	pb "michael/proto"
+)
+
+const interruptedReason = "Michael was interrupted"
+
+// heistInFlight is what the heist under way has running at the crew: the
+// phase an operator is playing and Lester's stars session.
+type heistInFlight struct {
+	mu           sync.Mutex
+	operator     *pb.OperatorServiceClient
+	operatorName string
+	phase        string
+	lester       pb.LesterServiceClient
+	session      *pb.NotificationSession
+}
+
+var underway = &heistInFlight{}
+
+func (h *heistInFlight) phaseStarted(oc *pb.OperatorServiceClient, ocName, phase string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.operator, h.operatorName, h.phase = oc, ocName, phase
+}
+
+func (h *heistInFlight) phaseEnded() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.operator, h.operatorName, h.phase = nil, "", ""
+}
+
+func (h *heistInFlight) sessionStarted(lester pb.LesterServiceClient, session *pb.NotificationSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lester, h.session = lester, session
+}
+
+func (h *heistInFlight) sessionEnded() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lester, h.session = nil, nil
+}
+
+// callOff stops Lester's stars session and aborts the operator's phase.
+func (h *heistInFlight) callOff() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.session != nil {
+		log.Printf("Stopping stars session %s", h.session.SessionId)
+		ctx, cancel := context.WithTimeout(context.Background(), statusDeadline)
+		_, err := h.lester.ManageStarsNotifications(ctx, &pb.NotificationCommand{
+			Command:   pb.NotificationCommand_STOP,
+			HeistId:   h.session.HeistId,
+			SessionId: h.session.SessionId,
+		})
+		cancel()
+		if err != nil {
+			log.Printf("Could not stop stars notifications: %v", err)
+		}
+	}
+	if h.operator != nil {
+		log.Printf("Aborting %s's %s", h.operatorName, h.phase)
+		ctx, cancel := context.WithTimeout(context.Background(), statusDeadline)
+		_, err := (*h.operator).AbortPhase(ctx, &pb.AbortRequest{Reason: interruptedReason})
+		cancel()
+		if err != nil {
+			log.Printf("Could not abort %s's %s: %v", h.operatorName, h.phase, err)
+		}
+	}
+}
+
+// handleInterrupts calls off the heist under way and exits on SIGINT or
+// SIGTERM. The checkpoint is kept, so `michael run -resume` starts the phase
+// that was called off again.
+func handleInterrupts() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("Received %s, calling off the heist", sig)
+		underway.callOff()
+		os.Exit(exitInterrupted)
+	}()
+}
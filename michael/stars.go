@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/protobuf/proto"
+
+	pb "michael/proto"
+)
+
+const starsExchangeName = "stars_notification"
+
+// reliableStars must match Lester's and the operators' RELIABLE_STARS, since
+// all of them declare the stars exchange.
+var reliableStars = os.Getenv("RELIABLE_STARS") == "true"
+
+func rabbitMQHost() string {
+	if host := os.Getenv("RABBITMQ_HOST"); host != "" {
+		return host
+	}
+	return "192.168.1.6"
+}
+
+// followStars shows on the dashboard the stars Lester publishes for the heist,
+// from the given count on, as they are published, until the returned function
+// is called. Without it the dashboard only has the stars the operator reports
+// when polled.
+func followStars(heistID string, stars int32) func() {
+	if dash == nil {
+		return func() {}
+	}
+	exchange := starsExchangeName + "." + heistID
+	conn, ch, msgs, queue, err := bindStarsQueue(exchange)
+	if err != nil {
+		log.Printf("Dashboard: could not follow the stars, showing the polled ones: %v", err)
+		return func() {}
+	}
+	dash.update(func(s *dashboardState) { s.Stars, s.StarsFrom = stars, "lester" })
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case d, ok := <-msgs:
+				if !ok {
+					return
+				}
+				var update pb.StarUpdate
+				if err := proto.Unmarshal(d.Body, &update); err != nil || update.HeistId != heistID {
+					continue
+				}
+				dash.stars(&update)
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+		dash.update(func(s *dashboardState) { s.StarsFrom = "" })
+		defer conn.Close()
+		if _, err := ch.QueueDelete(queue, false, false, false); err != nil {
+			log.Printf("Failed to delete queue %s: %v", queue, err)
+			return
+		}
+		// Lester and the operator leave the exchange to its last user.
+		err := ch.ExchangeDelete(exchange, true, false)
+		var amqpErr *amqp.Error
+		if err != nil && !(errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed) {
+			log.Printf("Failed to delete exchange %s: %v", exchange, err)
+		}
+	}
+}
+
+// bindStarsQueue binds a private queue to the heist's stars exchange and
+// consumes from it.
+func bindStarsQueue(exchange string) (*amqp.Connection, *amqp.Channel, <-chan amqp.Delivery, string, error) {
+	conn, err := amqp.Dial("amqp://admin:admin@" + rabbitMQHost() + ":5673/")
+	if err != nil {
+		return nil, nil, nil, "", fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to open a channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(exchange, amqp.ExchangeFanout, reliableStars, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to declare exchange %s: %w", exchange, err)
+	}
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to declare a queue: %w", err)
+	}
+	if err := ch.QueueBind(q.Name, "", exchange, false, nil); err != nil {
+		conn.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to bind queue to %s: %w", exchange, err)
+	}
+	msgs, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, "", fmt.Errorf("failed to register a consumer: %w", err)
+	}
+	return conn, ch, msgs, q.Name, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"michael/assign"
+	pb "michael/proto"
+)
+
+const (
+	defaultMinExpectedValue = 300000
+	defaultRiskPenalty      = 0.5
+)
+
+// offerStrategy decides whether Michael should accept an offer. Evaluate
+// returns the decision and the reasoning behind it.
+type offerStrategy interface {
+	Name() string
+	Evaluate(offer *pb.HeistOffer) (bool, string)
+}
+
+// newOfferStrategy picks the named strategy, "rule" or "ev"; an empty name
+// falls back to OFFER_STRATEGY. The expected-value strategy reads
+// MIN_EXPECTED_VALUE and RISK_PENALTY and plans with the planner and the
+// pinned phases.
+func newOfferStrategy(name string, planner *assign.Planner, pinned map[string]string) (offerStrategy, error) {
+	if name == "" {
+		name = os.Getenv("OFFER_STRATEGY")
+	}
+	switch name {
+	case "", "rule":
+		return ruleStrategy{}, nil
+	case "ev":
+		ev := expectedValueStrategy{minValue: defaultMinExpectedValue, riskPenalty: defaultRiskPenalty, planner: planner, pinned: pinned}
+		if v := os.Getenv("MIN_EXPECTED_VALUE"); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid MIN_EXPECTED_VALUE %q: %w", v, err)
+			}
+			ev.minValue = f
+		}
+		if v := os.Getenv("RISK_PENALTY"); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid RISK_PENALTY %q: %w", v, err)
+			}
+			ev.riskPenalty = f
+		}
+		return ev, nil
+	default:
+		return nil, fmt.Errorf("unknown offer strategy %q", name)
+	}
+}
+
+// ruleStrategy is the original rule: someone must be good enough and the
+// police risk must not be too high.
+type ruleStrategy struct{}
+
+func (ruleStrategy) Name() string { return "rule" }
+
+func (ruleStrategy) Evaluate(offer *pb.HeistOffer) (bool, string) {
+	accept := isOfferAcceptable(offer)
+	return accept, fmt.Sprintf("best success %d (needs > 50), police risk %d (needs < 80)",
+		bestSuccess(offer), offer.PoliceRisk)
+}
+
+// expectedValueStrategy estimates the chance of pulling off the heist with the
+// phases assigned by optimizeAssignment. The expected take, minus a penalty
+// proportional to the police risk, must reach minValue.
+type expectedValueStrategy struct {
+	minValue    float64
+	riskPenalty float64
+	planner     *assign.Planner
+	pinned      map[string]string
+}
+
+func (expectedValueStrategy) Name() string { return "ev" }
+
+func (s expectedValueStrategy) Evaluate(offer *pb.HeistOffer) (bool, string) {
+	plan, err := optimizeAssignment(s.planner, offer, s.pinned)
+	if err != nil {
+		return false, err.Error()
+	}
+	value := plan.Probability * (float64(offer.Loot) + plan.Extra)
+	penalty := s.riskPenalty * float64(offer.PoliceRisk) / 100 * float64(offer.Loot)
+	score := value - penalty
+	reason := fmt.Sprintf("%s (extra ~$%.0f, %s stars), expected $%.0f - risk penalty $%.0f = $%.0f vs threshold $%.0f",
+		plan, plan.Extra, starsModel, value, penalty, score, s.minValue)
+	return score >= s.minValue, reason
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "michael/proto"
+)
+
+// errPhaseTimedOut is returned when a phase runs past its watchdog.
+var errPhaseTimedOut = errors.New("phase timed out")
+
+// timeouts are how long Michael waits for the crew. Every RPC gets the
+// default deadline unless its method has its own, and a phase gets its turns
+// times the operators' turn duration plus some slack before the watchdog
+// aborts it.
+type timeouts struct {
+	rpc    time.Duration
+	perRPC map[string]time.Duration
+	turn   time.Duration
+	slack  time.Duration
+}
+
+// deadlines are the timeouts in use. ProposeHeistOffer waits out Lester's
+// 10 second cooldown, so it gets longer.
+var deadlines = timeouts{
+	rpc:    5 * time.Second,
+	perRPC: map[string]time.Duration{"ProposeHeistOffer": 15 * time.Second},
+	turn:   10 * time.Millisecond,
+	slack:  10 * time.Second,
+}
+
+// loadTimeouts reads RPC_TIMEOUT, RPC_TIMEOUTS (such as
+// "ProposeHeistOffer=20s,StartHit=2s"), TURN_DURATION and WATCHDOG_SLACK.
+func loadTimeouts() error {
+	for _, env := range []struct {
+		name string
+		dst  *time.Duration
+	}{
+		{"RPC_TIMEOUT", &deadlines.rpc},
+		{"TURN_DURATION", &deadlines.turn},
+		{"WATCHDOG_SLACK", &deadlines.slack},
+	} {
+		v := os.Getenv(env.name)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", env.name, v, err)
+		}
+		*env.dst = d
+	}
+	if v := os.Getenv("RPC_TIMEOUTS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			method, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			d, err := time.ParseDuration(value)
+			if !ok || err != nil {
+				return fmt.Errorf("invalid RPC_TIMEOUTS entry %q, want Method=duration", entry)
+			}
+			deadlines.perRPC[method] = d
+		}
+	}
+	return nil
+}
+
+// forMethod is the deadline of the gRPC method, such as
+// /heist.LesterService/ProposeHeistOffer.
+func (t timeouts) forMethod(method string) time.Duration {
+	if d, ok := t.perRPC[path.Base(method)]; ok {
+		return d
+	}
+	return t.rpc
+}
+
+// phase is how long a phase of the given turns may run before the watchdog
+// aborts it.
+func (t timeouts) phase(turns int32) time.Duration {
+	return time.Duration(turns)*t.turn + t.slack
+}
+
+// withDeadlines gives every call without a deadline the one of its method.
+func withDeadlines() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadlines.forMethod(method))
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// abortPhase asks the operator to give up on the phase the watchdog fired on.
+func abortPhase(oc *pb.OperatorServiceClient, ocName, phase string, limit time.Duration) error {
+	reason := fmt.Sprintf("Michael aborted the %s after %s", phase, limit)
+	log.Printf("Watchdog: %s", reason)
+	if _, err := (*oc).AbortPhase(context.Background(), &pb.AbortRequest{Reason: reason}); err != nil {
+		log.Printf("Could not abort %s's %s: %v", ocName, phase, err)
+	}
+	return fmt.Errorf("%w: the %s with %s took longer than %s", errPhaseTimedOut, phase, ocName, limit)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+
+	"trevor/phases"
+	pb "trevor/proto"
+)
+
+// auditKey is this operator's HMAC key. Cut confirmations are signed with it,
+// so Michael's audit log can prove what the operator answered.
+var auditKey = []byte(os.Getenv("AUDIT_KEY"))
+
+func init() {
+	if len(auditKey) == 0 {
+		log.Printf("AUDIT_KEY is not set, cut confirmations will not be signed")
+	}
+}
+
+// signCut signs the answer to a cut proposal. Michael verifies the signature
+// over the same fields when it checks the audit log.
+func signCut(details *pb.CutDetails, ack *pb.Ack) {
+	if len(auditKey) == 0 {
+		return
+	}
+	ack.SignedBy = phases.Name
+	mac := hmac.New(sha256.New, auditKey)
+	fmt.Fprintf(mac, "cut|%s|%s|%d|%d|%d|%d|%t|%s", ack.SignedBy, details.RequestId,
+		details.Loot, details.ExtraMoeny, details.ReceivedCut, details.CrewSize, ack.Acknowledged, ack.Message)
+	ack.Signature = mac.Sum(nil)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"trevor/phases"
+	pb "trevor/proto"
+)
+
+const interruptedMessage = "The phase was interrupted by a restart"
+
+// stateFile is where the phase state is saved after every turn and state
+// change, so a restarted operator picks up where it left off.
+var stateFile = os.Getenv("STATE_FILE")
+
+// recoveryPolicy says what to do with a phase interrupted by a restart:
+// "resume" it from the next turn (the default) or "fail" it.
+var recoveryPolicy = os.Getenv("RECOVERY_POLICY")
+
+type savedTransition struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Event string `json:"event"`
+	AtMs  int64  `json:"at_ms"`
+}
+
+// savedState is phaseState as it is written to stateFile.
+type savedState struct {
+	State         string            `json:"state"`
+	Message       string            `json:"message,omitempty"`
+	HeistID       string            `json:"heist_id,omitempty"`
+	Loot          int32             `json:"loot"`
+	Stars         int32             `json:"stars"`
+	ExtraMoney    int32             `json:"extra_money"`
+	TotalLoot     int32             `json:"total_loot"`
+	Turn          int32             `json:"turn"`
+	TurnsNeeded   int32             `json:"turns_needed"`
+	AbilityActive bool              `json:"ability_active"`
+	StarSequence  int64             `json:"star_sequence,omitempty"`
+	Interrupted   bool              `json:"interrupted,omitempty"`
+	History       []savedTransition `json:"history"`
+}
+
+// saveState writes phaseState to stateFile, replacing the previous copy
+// atomically. phaseState.mu must be held.
+func saveState() {
+	saved := savedState{
+		State:         phaseState.state.String(),
+		Message:       phaseState.message,
+		HeistID:       phaseState.heistID,
+		Loot:          phaseState.loot,
+		Stars:         phaseState.current_stars,
+		ExtraMoney:    phaseState.extraMoney,
+		TotalLoot:     phaseState.totalLoot,
+		Turn:          phaseState.turn,
+		TurnsNeeded:   phaseState.turnsNeeded,
+		AbilityActive: phaseState.abilityActive,
+		StarSequence:  phaseState.starSequence,
+		Interrupted:   phaseState.interrupted,
+	}
+	for _, t := range phaseState.history {
+		saved.History = append(saved.History, savedTransition{t.From.String(), t.To.String(), t.Event, t.AtMs})
+	}
+	data, err := json.Marshal(saved)
+	if err == nil {
+		err = writeAtomic(stateFile, data)
+	}
+	if err != nil {
+		log.Printf("Could not save the phase state: %v", err)
+	}
+}
+
+// writeAtomic replaces the file at path with data, so a crash leaves either
+// the old or the new copy.
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadState restores phaseState from stateFile, if there is one.
+func loadState() (bool, error) {
+	data, err := os.ReadFile(stateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var saved savedState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return false, fmt.Errorf("decoding %s: %w", stateFile, err)
+	}
+	state, ok := pb.StateTransition_State_value[saved.State]
+	if !ok {
+		return false, fmt.Errorf("unknown state %q in %s", saved.State, stateFile)
+	}
+
+	phaseState.state = pb.StateTransition_State(state)
+	phaseState.message = saved.Message
+	phaseState.heistID, phaseState.loot = saved.HeistID, saved.Loot
+	phaseState.current_stars = saved.Stars
+	phaseState.extraMoney, phaseState.totalLoot = saved.ExtraMoney, saved.TotalLoot
+	phaseState.turn, phaseState.turnsNeeded = saved.Turn, saved.TurnsNeeded
+	phaseState.abilityActive = saved.AbilityActive
+	phaseState.starSequence = saved.StarSequence
+	phaseState.interrupted = saved.Interrupted
+	phaseState.history = nil
+	for _, t := range saved.History {
+		phaseState.history = append(phaseState.history, &pb.StateTransition{
+			From:  pb.StateTransition_State(pb.StateTransition_State_value[t.From]),
+			To:    pb.StateTransition_State(pb.StateTransition_State_value[t.To]),
+			Event: t.Event,
+			AtMs:  t.AtMs,
+		})
+	}
+	return true, nil
+}
+
+// recoverState restores the phase state and the request log saved before a
+// restart and, under recoveryPolicy, resumes or fails the phase that was
+// running. An unknown recoveryPolicy stops the operator.
+func recoverState() {
+	switch recoveryPolicy {
+	case "", "resume", "fail":
+	default:
+		log.Fatalf("Invalid RECOVERY_POLICY %q, want resume or fail", recoveryPolicy)
+	}
+	if stateFile == "" {
+		stateFile = operatorName + "-state.json"
+	}
+	if err := requests.load(requestsFile()); err != nil {
+		log.Fatalf("Failed to recover the request log: %v", err)
+	}
+	phaseState.mu.Lock()
+	defer phaseState.mu.Unlock()
+	found, err := loadState()
+	if err != nil {
+		log.Fatalf("Failed to recover the phase state: %v", err)
+	}
+	if !found {
+		log.Printf("No saved phase state in %s, awaiting orders", stateFile)
+		return
+	}
+	log.Printf("Recovered phase state %s (turn %d of %d) from %s", phaseState.state, phaseState.turn, phaseState.turnsNeeded, stateFile)
+
+	if phaseState.state != pb.StateTransition_DISTRACTING && phaseState.state != pb.StateTransition_HITTING {
+		return
+	}
+	if phaseState.interrupted {
+		log.Printf("The %s was interrupted by a shutdown", phaseState.state)
+		phaseState.interrupted = false
+		saveState()
+	} else {
+		log.Printf("The %s was cut short by a crash", phaseState.state)
+	}
+	if recoveryPolicy == "fail" {
+		log.Printf("Failing the phase interrupted at turn %d", phaseState.turn)
+		phaseState.message = interruptedMessage
+		phaseState.current_stars = 0
+		advance(eventPhaseFailed)
+		return
+	}
+	from := phaseState.turn + 1
+	log.Printf("Resuming the %s from turn %d", phaseState.state, from)
+	if phaseState.state == pb.StateTransition_DISTRACTING {
+		workers.Add(1)
+		go distract(phases.Distraction{TurnsNeeded: phaseState.turnsNeeded}, from)
+		return
+	}
+	hit := phases.Hit{
+		TurnsNeeded:   phaseState.turnsNeeded,
+		AbilityActive: phaseState.abilityActive,
+		ExtraMoney:    phaseState.extraMoney,
+	}
+	workers.Add(1)
+	go playHit(hit, from, watchStars(phaseState.heistID, phaseState.starSequence))
+}
@@ -0,0 +1,49 @@
+// Package phases holds Trevor's rules for the distraction and the hit, one
+// turn at a time, so the same rules drive the gRPC server and the simulator.
+package phases
+
+const (
+	Name = "Trevor"
+
+	// DistractionFailureOdds is the chance, out of 100, of the distraction
+	// failing at its midway turn.
+	DistractionFailureOdds    = 10
+	DistractionFailureMessage = "Trevor was too drunk!"
+
+	// Trevor flies into a rage once the stars reach AbilityStars, which lets
+	// him hold out until FailStars. The rage earns ExtraPerTurn every turn.
+	AbilityStars      = 5
+	FailStars         = 7
+	ExtraPerTurn      = 0
+	HitFailureMessage = "Too many stars! The cops arrived!"
+)
+
+// Distraction is a distraction lasting TurnsNeeded turns.
+type Distraction struct {
+	TurnsNeeded int32
+}
+
+// Turn plays the given turn, rolling with intn (such as rand.Intn) at the
+// midway point. It reports whether the distraction failed.
+func (d *Distraction) Turn(turn int32, intn func(int) int) bool {
+	return turn == d.TurnsNeeded/2 && intn(100) < DistractionFailureOdds
+}
+
+// Hit is a hit lasting TurnsNeeded turns and what it has earned so far.
+type Hit struct {
+	TurnsNeeded   int32
+	AbilityActive bool
+	ExtraMoney    int32
+}
+
+// Turn plays one turn of the hit with the current star count. It reports
+// whether the hit failed.
+func (h *Hit) Turn(stars int32) bool {
+	if stars >= AbilityStars {
+		h.AbilityActive = true
+	}
+	if h.AbilityActive {
+		h.ExtraMoney += ExtraPerTurn
+	}
+	return h.AbilityActive && stars >= FailStars
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const maxRememberedRequests = 256
+
+type requestResult struct {
+	method  string
+	request []byte
+	reply   proto.Message
+}
+
+// runningRequest serializes the calls with the same request ID. waiters
+// counts the calls holding or waiting for it.
+type runningRequest struct {
+	sync.Mutex
+	waiters int
+}
+
+// requestLog remembers the replies to the phase RPCs by request ID, so a
+// request Michael retries is answered with the original reply instead of being
+// run again. The replies are saved to path, so they survive a restart.
+type requestLog struct {
+	mu      sync.Mutex
+	results map[string]requestResult
+	order   []string
+	running map[string]*runningRequest
+	path    string
+}
+
+var requests = &requestLog{results: make(map[string]requestResult), running: make(map[string]*runningRequest)}
+
+// do runs the request once per request ID and replays the reply to repeats.
+// Calls with the same ID run one at a time; other IDs do not wait for them.
+// Failed requests are not remembered, so they can be retried, and requests
+// without an ID always run.
+func (l *requestLog) do(id, method string, req proto.Message, run func() (proto.Message, error)) (proto.Message, error) {
+	if id == "" {
+		return run()
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding request: %v", err)
+	}
+	l.lock(id)
+	defer l.unlock(id)
+
+	l.mu.Lock()
+	prev, ok := l.results[id]
+	l.mu.Unlock()
+	if ok {
+		if prev.method != method || !bytes.Equal(prev.request, data) {
+			return nil, status.Errorf(codes.InvalidArgument, "request ID %s was already used for a different %s request", id, prev.method)
+		}
+		return proto.Clone(prev.reply), nil
+	}
+
+	reply, err := run()
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.results[id] = requestResult{method: method, request: data, reply: proto.Clone(reply)}
+	l.order = append(l.order, id)
+	if len(l.order) > maxRememberedRequests {
+		delete(l.results, l.order[0])
+		l.order = l.order[1:]
+	}
+	if err := l.save(); err != nil {
+		log.Printf("Could not save the request log: %v", err)
+	}
+	return reply, nil
+}
+
+func (l *requestLog) lock(id string) {
+	l.mu.Lock()
+	r := l.running[id]
+	if r == nil {
+		r = &runningRequest{}
+		l.running[id] = r
+	}
+	r.waiters++
+	l.mu.Unlock()
+	r.Lock()
+}
+
+func (l *requestLog) unlock(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r := l.running[id]
+	r.Unlock()
+	if r.waiters--; r.waiters == 0 {
+		delete(l.running, id)
+	}
+}
+
+// savedRequest is a remembered reply as it is written to the request log file.
+type savedRequest struct {
+	ID        string `json:"id"`
+	Method    string `json:"method"`
+	Request   []byte `json:"request"`
+	ReplyType string `json:"reply_type"`
+	Reply     []byte `json:"reply"`
+}
+
+// requestsFile is where the request log is saved, next to stateFile.
+func requestsFile() string {
+	return filepath.Join(filepath.Dir(stateFile), operatorName+"-requests.json")
+}
+
+// save writes the remembered replies to the request log file. l.mu must be
+// held.
+func (l *requestLog) save() error {
+	if l.path == "" {
+		return nil
+	}
+	saved := make([]savedRequest, 0, len(l.order))
+	for _, id := range l.order {
+		result := l.results[id]
+		reply, err := proto.Marshal(result.reply)
+		if err != nil {
+			return err
+		}
+		saved = append(saved, savedRequest{
+			ID:        id,
+			Method:    result.method,
+			Request:   result.request,
+			ReplyType: string(result.reply.ProtoReflect().Descriptor().FullName()),
+			Reply:     reply,
+		})
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(l.path, data)
+}
+
+// load restores the replies saved in path, which the log saves to from then on.
+func (l *requestLog) load(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var saved []savedRequest
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	for _, s := range saved {
+		typ, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(s.ReplyType))
+		if err != nil {
+			return fmt.Errorf("request %s in %s: %w", s.ID, path, err)
+		}
+		reply := typ.New().Interface()
+		if err := proto.Unmarshal(s.Reply, reply); err != nil {
+			return fmt.Errorf("request %s in %s: %w", s.ID, path, err)
+		}
+		if _, ok := l.results[s.ID]; !ok {
+			l.order = append(l.order, s.ID)
+		}
+		l.results[s.ID] = requestResult{method: s.Method, request: s.Request, reply: reply}
+	}
+	return nil
+}
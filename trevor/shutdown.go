@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "trevor/proto"
+)
+
+// workers are the goroutines playing a phase or following its stars.
+var workers sync.WaitGroup
+
+// stopping is closed on shutdown. It stops the workers without ending the
+// phase they play.
+var stopping = make(chan struct{})
+
+func shuttingDown() bool {
+	select {
+	case <-stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdownTimeout is how long the running calls get to finish once a shutdown
+// signal arrives.
+const shutdownTimeout = 15 * time.Second
+
+// serveUntilSignal serves until SIGINT or SIGTERM, then stops taking calls
+// and waits up to shutdownTimeout for the running ones to finish.
+func serveUntilSignal(server *grpc.Server, lis net.Listener) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		log.Printf("Shutting down, waiting up to %s for running calls", shutdownTimeout)
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			log.Printf("Calls still running after %s, closing them", shutdownTimeout)
+			server.Stop()
+		}
+	}()
+	return server.Serve(lis)
+}
+
+// interruptPhase stops the phase in progress, if any, and waits for its
+// goroutines to stop. The phase is saved marked as interrupted, with an entry
+// in its history, so recoverState resumes or fails it under recoveryPolicy
+// when the operator restarts.
+func interruptPhase() {
+	phaseState.mu.Lock()
+	if phaseStatus(phaseState.state) == pb.PhaseStatus_IN_PROGESS {
+		log.Printf("Interrupting the %s at turn %d, it is left to the recovery policy", phaseState.state, phaseState.turn)
+		phaseState.interrupted = true
+		phaseState.history = append(phaseState.history, &pb.StateTransition{
+			From:  phaseState.state,
+			To:    phaseState.state,
+			Event: eventInterrupted,
+			AtMs:  time.Now().UnixMilli(),
+		})
+		saveState()
+	}
+	close(stopping)
+	phaseState.mu.Unlock()
+	workers.Wait()
+}
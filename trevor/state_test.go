@@ -0,0 +1,94 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "trevor/proto"
+)
+
+func TestTransition(t *testing.T) {
+	const (
+		awaiting    = pb.StateTransition_AWAITING_ORDERS
+		distracting = pb.StateTransition_DISTRACTING
+		distracted  = pb.StateTransition_DISTRACTED
+		hitting     = pb.StateTransition_HITTING
+		lootReady   = pb.StateTransition_LOOT_READY
+		paid        = pb.StateTransition_PAID
+		failed      = pb.StateTransition_FAILED
+	)
+	tests := []struct {
+		from  pb.StateTransition_State
+		event string
+		to    pb.StateTransition_State // unused when refused
+		ok    bool
+	}{
+		{awaiting, eventStartDistraction, distracting, true},
+		{awaiting, eventStartHit, hitting, true},
+		{awaiting, eventRetrieveLoot, 0, false},
+		{awaiting, eventPaid, 0, false},
+		{awaiting, eventAbort, 0, false},
+		{distracting, eventDistracted, distracted, true},
+		{distracting, eventPhaseFailed, failed, true},
+		{distracting, eventAbort, failed, true},
+		{distracting, eventStartDistraction, 0, false},
+		{distracting, eventStartHit, 0, false},
+		{distracted, eventStartHit, hitting, true},
+		{distracted, eventPaid, paid, true},
+		{distracted, eventDistracted, 0, false},
+		{hitting, eventHitDone, lootReady, true},
+		{hitting, eventPhaseFailed, failed, true},
+		{hitting, eventAbort, failed, true},
+		{hitting, eventStartHit, 0, false},
+		{hitting, eventRetrieveLoot, 0, false},
+		{lootReady, eventRetrieveLoot, lootReady, true},
+		{lootReady, eventPaid, paid, true},
+		{lootReady, eventStartDistraction, 0, false},
+		{lootReady, eventStartHit, 0, false},
+		{lootReady, eventAbort, 0, false},
+		{paid, eventStartDistraction, distracting, true},
+		{paid, eventPaid, 0, false},
+		{failed, eventStartHit, hitting, true},
+		{failed, eventRetrieveLoot, 0, false},
+		{failed, eventPhaseFailed, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"/"+tt.event, func(t *testing.T) {
+			stateFile = filepath.Join(t.TempDir(), "state.json")
+			phaseState.state, phaseState.history = tt.from, nil
+			err := transition(tt.event)
+			if !tt.ok {
+				if status.Code(err) != codes.FailedPrecondition {
+					t.Fatalf("got %v, want FailedPrecondition", err)
+				}
+				if phaseState.state != tt.from || len(phaseState.history) != 0 {
+					t.Errorf("refused event moved the operator to %s with history %v", phaseState.state, phaseState.history)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if phaseState.state != tt.to {
+				t.Errorf("got %s, want %s", phaseState.state, tt.to)
+			}
+			// Events that keep the state are not recorded.
+			want := 1
+			if tt.to == tt.from {
+				want = 0
+			}
+			if len(phaseState.history) != want {
+				t.Fatalf("got %d transitions in the history, want %d", len(phaseState.history), want)
+			}
+			if want == 1 {
+				h := phaseState.history[0]
+				if h.From != tt.from || h.To != tt.to || h.Event != tt.event {
+					t.Errorf("history has %s -> %s (%s)", h.From, h.To, h.Event)
+				}
+			}
+		})
+	}
+}